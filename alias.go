@@ -0,0 +1,57 @@
+package updown
+
+import (
+	"context"
+	"fmt"
+)
+
+// AliasTakenError reports that the alias of a check about to be created is already used by another check
+type AliasTakenError struct {
+	// The alias in use
+	Alias string
+	// Token of the check using it
+	Token string
+}
+
+func (e *AliasTakenError) Error() string {
+	return fmt.Sprintf("alias %q is already used by check %s", e.Alias, e.Token)
+}
+
+// EnsureUniqueAlias verifies that no check uses the alias of the given item before creating it, as
+// TokenForAlias cannot tell such checks apart. When the alias is taken, it returns an *AliasTakenError,
+// or when autoSuffix is set, the item with the first free alias among "alias (2)", "alias (3)"...
+func (s *CheckService) EnsureUniqueAlias(item CheckItem, autoSuffix bool) (CheckItem, error) {
+	return s.EnsureUniqueAliasCtx(context.Background(), item, autoSuffix)
+}
+
+// EnsureUniqueAliasCtx is like EnsureUniqueAlias, with a context
+func (s *CheckService) EnsureUniqueAliasCtx(ctx context.Context, item CheckItem, autoSuffix bool) (CheckItem, error) {
+	if item.Alias == "" {
+		return item, nil
+	}
+
+	checks, _, err := s.ListCtx(ctx)
+	if err != nil {
+		return item, err
+	}
+	taken := make(map[string]string, len(checks))
+	for _, check := range checks {
+		taken[check.Alias] = check.Token
+	}
+
+	token, found := taken[item.Alias]
+	if !found {
+		return item, nil
+	}
+	if !autoSuffix {
+		return item, &AliasTakenError{Alias: item.Alias, Token: token}
+	}
+
+	for i := 2; ; i++ {
+		alias := fmt.Sprintf("%s (%d)", item.Alias, i)
+		if _, found := taken[alias]; !found {
+			item.Alias = alias
+			return item, nil
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package updown
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureUniqueAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"token":"abcd","alias":"API"},{"token":"efgh","alias":"API (2)"}]`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	item, err := client.Check.EnsureUniqueAlias(CheckItem{Alias: "Website"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Website", item.Alias)
+
+	_, err = client.Check.EnsureUniqueAlias(CheckItem{Alias: "API"}, false)
+	var taken *AliasTakenError
+	require.True(t, errors.As(err, &taken))
+	assert.Equal(t, &AliasTakenError{Alias: "API", Token: "abcd"}, taken)
+
+	item, err = client.Check.EnsureUniqueAlias(CheckItem{Alias: "API", URL: "https://example.com"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, CheckItem{Alias: "API (3)", URL: "https://example.com"}, item)
+}
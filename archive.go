@@ -0,0 +1,181 @@
+package updown
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DefaultArchiveInterval is how often MetricsArchiver.Run pulls metrics by default
+const DefaultArchiveInterval = time.Hour
+
+// archiveHeader lists the columns of the CSV files written by MetricsArchiver
+var archiveHeader = []string{
+	"time", "apdex", "samples", "failures", "satisfied", "tolerated",
+	"namelookup", "connection", "handshake", "response", "total",
+}
+
+// MetricsArchiver appends the metrics of every check, grouped by time, to a CSV file per check,
+// to keep their history beyond the retention of the API, e.g. for capacity planning
+type MetricsArchiver struct {
+	Client *Client
+	// Directory of the CSV files, named after the check tokens
+	Dir string
+	// Interval between pulls of Run, DefaultArchiveInterval when zero
+	Interval time.Duration
+}
+
+// NewMetricsArchiver creates an archiver writing CSV files to the given directory
+func NewMetricsArchiver(client *Client, dir string) *MetricsArchiver {
+	return &MetricsArchiver{Client: client, Dir: dir, Interval: DefaultArchiveInterval}
+}
+
+// Run archives metrics right away, then periodically until the context is done
+func (a *MetricsArchiver) Run(ctx context.Context) error {
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DefaultArchiveInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := a.Archive(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Archive appends the metrics of every check which are not archived yet. The latest period
+// is left out, as it may still be incomplete
+func (a *MetricsArchiver) Archive(ctx context.Context) error {
+	if err := os.MkdirAll(a.Dir, 0o755); err != nil {
+		return err
+	}
+
+	checks, _, err := a.Client.Check.ListCtx(ctx)
+	if err != nil {
+		return err
+	}
+	for _, check := range checks {
+		if err := a.archiveCheck(ctx, check.Token); err != nil {
+			return fmt.Errorf("archiving metrics of %s: %w", check.Token, err)
+		}
+	}
+	return nil
+}
+
+// Path gives the path of the CSV file of a check
+func (a *MetricsArchiver) Path(token string) string {
+	return filepath.Join(a.Dir, token+".csv")
+}
+
+func (a *MetricsArchiver) archiveCheck(ctx context.Context, token string) error {
+	path := a.Path(token)
+	last, err := lastArchived(path)
+	if err != nil {
+		return err
+	}
+
+	var from string
+	if !last.IsZero() {
+		from = last.Format(time.RFC3339)
+	}
+	metrics, _, err := a.Client.Metric.ListCtx(ctx, token, "time", from, "")
+	if err != nil {
+		return err
+	}
+
+	type row struct {
+		at time.Time
+		m  MetricItem
+	}
+	var rows []row
+	for key, m := range metrics {
+		at, err := time.Parse(time.RFC3339, key)
+		if err != nil || !at.After(last) {
+			continue
+		}
+		rows = append(rows, row{at: at, m: m})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].at.Before(rows[j].at) })
+	if len(rows) > 0 {
+		rows = rows[:len(rows)-1]
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if last.IsZero() {
+		w.Write(archiveHeader)
+	}
+	for _, r := range rows {
+		w.Write(archiveRecord(r.at, r.m))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func archiveRecord(at time.Time, m MetricItem) []string {
+	return []string{
+		at.UTC().Format(time.RFC3339),
+		strconv.FormatFloat(m.Apdex, 'f', -1, 64),
+		strconv.Itoa(m.Requests.Samples),
+		strconv.Itoa(m.Requests.Failures),
+		strconv.Itoa(m.Requests.Satisfied),
+		strconv.Itoa(m.Requests.Tolerated),
+		strconv.Itoa(m.Timings.NameLookup),
+		strconv.Itoa(m.Timings.Connection),
+		strconv.Itoa(m.Timings.Handshake),
+		strconv.Itoa(m.Timings.Response),
+		strconv.Itoa(m.Timings.Total),
+	}
+}
+
+// lastArchived gives the time of the last row of a CSV file, zero when it has none
+func lastArchived(path string) (time.Time, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	var last time.Time
+	r := csv.NewReader(f)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return last, nil
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if at, err := time.Parse(time.RFC3339, record[0]); err == nil {
+			last = at
+		}
+	}
+}
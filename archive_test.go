@@ -0,0 +1,52 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsArchiver(t *testing.T) {
+	var froms []string
+	metrics := `{
+		"2024-01-01T10:00:00Z": {"apdex": 0.98, "requests": {"samples": 60, "failures": 1}, "timings": {"total": 120}},
+		"2024-01-01T11:00:00Z": {"apdex": 1, "requests": {"samples": 60}, "timings": {"total": 110}},
+		"2024-01-01T12:00:00Z": {"requests": {"samples": 10}, "timings": {"total": 100}}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/checks" {
+			w.Write([]byte(`[{"token":"abcd"}]`))
+			return
+		}
+		froms = append(froms, r.URL.Query().Get("from"))
+		w.Write([]byte(metrics))
+	}))
+	defer server.Close()
+
+	archiver := NewMetricsArchiver(newTestClient(t, server.URL), t.TempDir())
+	require.NoError(t, archiver.Archive(t.Context()))
+
+	// The latest hour may be incomplete, it is left for the next run
+	data, err := os.ReadFile(archiver.Path("abcd"))
+	require.NoError(t, err)
+	assert.Equal(t, "time,apdex,samples,failures,satisfied,tolerated,namelookup,connection,handshake,response,total\n"+
+		"2024-01-01T10:00:00Z,0.98,60,1,0,0,0,0,0,0,120\n"+
+		"2024-01-01T11:00:00Z,1,60,0,0,0,0,0,0,0,110\n", string(data))
+
+	metrics = `{
+		"2024-01-01T11:00:00Z": {"apdex": 1, "requests": {"samples": 60}, "timings": {"total": 110}},
+		"2024-01-01T12:00:00Z": {"apdex": 1, "requests": {"samples": 60}, "timings": {"total": 100}},
+		"2024-01-01T13:00:00Z": {"requests": {"samples": 5}}
+	}`
+	require.NoError(t, archiver.Archive(t.Context()))
+	assert.Equal(t, []string{"", "2024-01-01T11:00:00Z"}, froms)
+
+	data, err = os.ReadFile(archiver.Path("abcd"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "2024-01-01T11:00:00Z,1,60,0,0,0,0,0,0,0,110\n2024-01-01T12:00:00Z,1,60,0,0,0,0,0,0,0,100\n")
+	assert.NotContains(t, string(data), "13:00")
+}
@@ -0,0 +1,92 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// LocationFailures counts the failed requests made from a monitoring location
+type LocationFailures struct {
+	// Key of the location in metrics grouped by host, e.g. "fra"
+	Node     string
+	Host     Host
+	Samples  int
+	Failures int
+}
+
+// FailureRate gives the share of failed requests
+func (l LocationFailures) FailureRate() float64 {
+	if l.Samples == 0 {
+		return 0
+	}
+	return float64(l.Failures) / float64(l.Samples)
+}
+
+// DowntimeAttribution tells from which monitoring locations a downtime was seen
+type DowntimeAttribution struct {
+	Downtime Downtime
+	// Locations which saw failures, the highest failure rate first
+	Failing []LocationFailures
+	// Locations which saw no failures
+	Healthy []LocationFailures
+}
+
+// Regional tells if the downtime was only seen from some locations, hinting at a network
+// problem rather than the monitored service being down
+func (a DowntimeAttribution) Regional() bool {
+	return a.Downtime.Partial || (len(a.Failing) > 0 && len(a.Healthy) > 0)
+}
+
+// AttributeDowntime splits the locations of metrics grouped by host, covering the downtime,
+// between the ones which saw failures and the ones which did not
+func AttributeDowntime(d Downtime, metrics Metrics) DowntimeAttribution {
+	res := DowntimeAttribution{Downtime: d}
+	for node, m := range metrics {
+		l := LocationFailures{Node: node, Host: m.Host, Samples: m.Requests.Samples, Failures: m.Requests.Failures}
+		if l.Failures > 0 {
+			res.Failing = append(res.Failing, l)
+		} else {
+			res.Healthy = append(res.Healthy, l)
+		}
+	}
+
+	sort.Slice(res.Failing, func(i, j int) bool {
+		if ri, rj := res.Failing[i].FailureRate(), res.Failing[j].FailureRate(); ri != rj {
+			return ri > rj
+		}
+		return res.Failing[i].Node < res.Failing[j].Node
+	})
+	sort.Slice(res.Healthy, func(i, j int) bool {
+		return res.Healthy[i].Node < res.Healthy[j].Node
+	})
+	return res
+}
+
+// Attribute tells from which monitoring locations each downtime of a check was seen, using
+// metrics grouped by host over the downtime. Metrics are aggregated by the API, so short
+// downtimes may be diluted among the successful requests around them
+func (s *DowntimeService) Attribute(token string, downtimes []Downtime) ([]DowntimeAttribution, *http.Response, error) {
+	return s.AttributeCtx(context.Background(), token, downtimes)
+}
+
+// AttributeCtx is like Attribute, with a context
+func (s *DowntimeService) AttributeCtx(ctx context.Context, token string, downtimes []Downtime) ([]DowntimeAttribution, *http.Response, error) {
+	var res []DowntimeAttribution
+	var resp *http.Response
+	for _, d := range downtimes {
+		from, to, ok := downtimeBounds(d)
+		if !ok {
+			continue
+		}
+
+		metrics, r, err := s.client.Metric.ListCtx(ctx, token, "host", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		res = append(res, AttributeDowntime(d, metrics))
+	}
+	return res, resp, nil
+}
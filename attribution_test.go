@@ -0,0 +1,53 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeDowntime(t *testing.T) {
+	metrics := Metrics{
+		"fra": {Requests: Requests{Samples: 10, Failures: 2}},
+		"sgp": {Requests: Requests{Samples: 10, Failures: 8}, Host: Host{City: "Singapore"}},
+		"nyc": {Requests: Requests{Samples: 10}},
+	}
+
+	res := AttributeDowntime(Downtime{Error: "timeout"}, metrics)
+	require.Len(t, res.Failing, 2)
+	assert.Equal(t, "sgp", res.Failing[0].Node)
+	assert.Equal(t, "Singapore", res.Failing[0].Host.City)
+	assert.Equal(t, 0.8, res.Failing[0].FailureRate())
+	assert.Equal(t, "fra", res.Failing[1].Node)
+	require.Len(t, res.Healthy, 1)
+	assert.Equal(t, "nyc", res.Healthy[0].Node)
+	assert.True(t, res.Regional())
+
+	delete(metrics, "nyc")
+	assert.False(t, AttributeDowntime(Downtime{}, metrics).Regional())
+	assert.True(t, AttributeDowntime(Downtime{Partial: true}, metrics).Regional())
+}
+
+func TestDowntimeAttribute(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("from")+" "+r.URL.Query().Get("to"))
+		w.Write([]byte(`{"fra":{"requests":{"samples":4,"failures":4}},"nyc":{"requests":{"samples":4}}}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	downtimes := []Downtime{
+		{StartedAt: "2024-01-01T10:00:00Z", EndedAt: "2024-01-01T10:30:00Z", Partial: true},
+		{StartedAt: "invalid"},
+	}
+	res, _, err := client.Downtime.Attribute("abcd", downtimes)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, []string{"2024-01-01T10:00:00Z 2024-01-01T10:30:00Z"}, queries)
+	assert.Equal(t, "fra", res[0].Failing[0].Node)
+	assert.True(t, res[0].Regional())
+}
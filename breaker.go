@@ -0,0 +1,115 @@
+package updown
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the API while the circuit breaker is open
+var ErrCircuitOpen = errors.New("Circuit breaker is open, the API failed repeatedly")
+
+// CircuitState is the state of the circuit breaker of a client
+type CircuitState int
+
+const (
+	// Requests go through
+	CircuitClosed CircuitState = iota
+	// Requests fail fast with ErrCircuitOpen
+	CircuitOpen
+	// A trial request goes through, others fail fast until it completes
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// WithCircuitBreaker makes requests fail fast with ErrCircuitOpen for the cooldown period
+// after the given number of consecutive failures (network errors and 5xx statuses), so a
+// degraded API is not hammered. A single trial request is then let through, closing the
+// circuit when it succeeds
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) error {
+		if threshold < 1 {
+			return errors.New("updown: circuit breaker threshold must be positive")
+		}
+		c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+		return nil
+	}
+}
+
+// CircuitState gives the state of the circuit breaker, always closed without circuit breaker
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     CircuitState
+	openedAt  time.Time
+	trial     bool
+}
+
+// State gives the current state, an open circuit becoming half-open after the cooldown
+func (b *circuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current()
+}
+
+func (b *circuitBreaker) current() CircuitState {
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		b.state = CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow tells if a request may be sent
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current() {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the state with the outcome of an allowed request. Requests which
+// neither succeeded nor failed, e.g. cancelled ones, only end the trial
+func (b *circuitBreaker) record(success, failure bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	switch {
+	case success:
+		b.failures, b.state = 0, CircuitClosed
+	case failure:
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+			b.state, b.openedAt = CircuitOpen, time.Now()
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	failing, calls := true, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, WithCircuitBreaker(2, 50*time.Millisecond))
+	assert.Equal(t, CircuitClosed, client.CircuitState())
+
+	// Opens after 2 consecutive failures, then fails fast
+	client.Check.List()
+	client.Check.List()
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+	_, _, err := client.Check.List()
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, 2, calls)
+
+	// A failing trial opens it again
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, CircuitHalfOpen, client.CircuitState())
+	client.Check.List()
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+	assert.Equal(t, 3, calls)
+
+	// A successful trial closes it
+	failing = false
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitState())
+	assert.Equal(t, "closed", client.CircuitState().String())
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, WithCircuitBreaker(1, time.Minute))
+	client.Check.Get("abcd")
+	client.Check.Get("abcd")
+	assert.Equal(t, CircuitClosed, client.CircuitState())
+}
+
+func TestCircuitBreakerWithResponseCache(t *testing.T) {
+	failing, calls := false, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if failing && r.URL.Path == "/checks" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL,
+		WithCircuitBreaker(1, 50*time.Millisecond),
+		WithResponseCache(NewMemoryResponseCache(), time.Minute),
+	)
+	_, _, err := client.Check.Get("abcd")
+	require.NoError(t, err)
+
+	failing = true
+	client.Check.List()
+	assert.Equal(t, CircuitOpen, client.CircuitState())
+
+	// Cached responses are served even when the circuit is open
+	_, _, err = client.Check.Get("abcd")
+	require.NoError(t, err)
+
+	// A cached response is not taken as the trial of a half-open circuit
+	time.Sleep(60 * time.Millisecond)
+	_, _, err = client.Check.Get("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, CircuitHalfOpen, client.CircuitState())
+	assert.Equal(t, 2, calls)
+
+	failing = false
+	_, _, err = client.Check.Get("efgh")
+	require.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.CircuitState())
+}
@@ -0,0 +1,45 @@
+package updown
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckBudgetError reports that adding checks would exceed the budget set with Client.MaxChecks
+type CheckBudgetError struct {
+	// Number of checks in the account
+	Current int
+	// Number of checks about to be added
+	Adding int
+	// Maximum number of checks allowed
+	Max int
+}
+
+func (e *CheckBudgetError) Error() string {
+	return fmt.Sprintf("adding %d checks to the %d existing ones would exceed the budget of %d checks",
+		e.Adding, e.Current, e.Max)
+}
+
+// EnsureBudget verifies that adding the given number of checks keeps the account within
+// Client.MaxChecks. It returns a *CheckBudgetError otherwise, which callers may treat as a
+// warning instead. Without a budget, no API call is made
+func (s *CheckService) EnsureBudget(adding int) error {
+	return s.EnsureBudgetCtx(context.Background(), adding)
+}
+
+// EnsureBudgetCtx is like EnsureBudget, with a context
+func (s *CheckService) EnsureBudgetCtx(ctx context.Context, adding int) error {
+	if s.client.MaxChecks <= 0 || adding <= 0 {
+		return nil
+	}
+
+	checks, _, err := s.ListCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(checks)+adding > s.client.MaxChecks {
+		return &CheckBudgetError{Current: len(checks), Adding: adding, Max: s.client.MaxChecks}
+	}
+	return nil
+}
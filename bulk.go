@@ -0,0 +1,208 @@
+package updown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultBulkRate is the number of API calls per minute performed by a BulkScheduler
+// when no rate is given
+const DefaultBulkRate = 60
+
+// BulkOperation is a single API call performed by a BulkScheduler
+type BulkOperation struct {
+	// Unique key of the operation, used to resume an interrupted run
+	Key string
+	// The API call to perform
+	Do func() error
+	// The operation adds a check to the account
+	AddsCheck bool
+}
+
+// BulkScheduler spreads a large batch of API calls over time to stay within the
+// rate limit of the account
+type BulkScheduler struct {
+	// Maximum number of API calls per minute
+	RatePerMinute int
+
+	// Progress is notified of the progress of the run
+	Progress Progress
+
+	// Completed holds the keys of the operations already performed. Operations listed
+	// here are skipped, so a run interrupted by an error or a cancelled context can be
+	// resumed by calling Run again with the same operations
+	Completed map[string]bool
+
+	// Checkpoint, when set, persists completed operations so a run can be resumed
+	// after the process was interrupted
+	Checkpoint Checkpoint
+
+	// ContinueOnError performs the remaining operations when one fails, instead of stopping.
+	// Run then returns a *MultiError listing the failed operations
+	ContinueOnError bool
+
+	// CheckGuard, when set, is called before any API call with the number of pending
+	// operations adding a check. Returning an error aborts the run. Check.EnsureBudget
+	// can be used to enforce Client.MaxChecks
+	CheckGuard func(adding int) error
+}
+
+// NewBulkScheduler creates a scheduler performing at most ratePerMinute API calls per minute
+func NewBulkScheduler(ratePerMinute int) *BulkScheduler {
+	return &BulkScheduler{
+		RatePerMinute: ratePerMinute,
+		Completed:     make(map[string]bool),
+	}
+}
+
+// Run performs the operations in order, waiting between API calls to respect the rate.
+// It stops at the first failing operation, unless ContinueOnError is set, or when the context
+// is cancelled. Calling Run again with the same operations only retries the failed ones
+func (s *BulkScheduler) Run(ctx context.Context, ops []BulkOperation) error {
+	progress := s.Progress
+	if progress == nil {
+		progress = noProgress{}
+	}
+
+	progress.OnStart(len(ops))
+	done, err := s.run(ctx, ops, progress)
+	progress.OnDone(done, len(ops), err)
+	return err
+}
+
+func (s *BulkScheduler) run(ctx context.Context, ops []BulkOperation, progress Progress) (int, error) {
+	if s.Completed == nil {
+		s.Completed = make(map[string]bool)
+	}
+	if s.Checkpoint != nil {
+		completed, err := s.Checkpoint.Load()
+		if err != nil {
+			return 0, fmt.Errorf("loading checkpoint: %w", err)
+		}
+		for key := range completed {
+			s.Completed[key] = true
+		}
+	}
+
+	if s.CheckGuard != nil {
+		adding := 0
+		for _, op := range ops {
+			if op.AddsCheck && !s.Completed[op.Key] {
+				adding++
+			}
+		}
+		if err := s.CheckGuard(adding); err != nil {
+			return 0, err
+		}
+	}
+
+	rate := s.RatePerMinute
+	if rate <= 0 {
+		rate = DefaultBulkRate
+	}
+	interval := time.Minute / time.Duration(rate)
+
+	done, last := 0, time.Time{}
+	var failed MultiError
+	for _, op := range ops {
+		if !s.Completed[op.Key] {
+			if wait := interval - time.Since(last); !last.IsZero() && wait > 0 {
+				select {
+				case <-ctx.Done():
+					return done, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return done, err
+			}
+
+			last = time.Now()
+			if err := op.Do(); err != nil {
+				progress.OnItem(op.Key, done, len(ops), err)
+				if !s.ContinueOnError {
+					return done, &BulkError{Key: op.Key, Err: err}
+				}
+				failed.Errors = append(failed.Errors, &BulkError{Key: op.Key, Err: err})
+				continue
+			}
+			s.Completed[op.Key] = true
+			if s.Checkpoint != nil {
+				if err := s.Checkpoint.Save(s.Completed); err != nil {
+					return done, fmt.Errorf("saving checkpoint: %w", err)
+				}
+			}
+		}
+
+		done++
+		progress.OnItem(op.Key, done, len(ops), nil)
+	}
+
+	if len(failed.Errors) > 0 {
+		return done, &failed
+	}
+	return done, nil
+}
+
+// BulkError reports a failed bulk operation
+type BulkError struct {
+	Key string
+	Err error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("bulk operation %s: %v", e.Key, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError reports the operations which failed during a bulk run, see BulkScheduler.ContinueOnError
+type MultiError struct {
+	Errors []*BulkError
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d bulk operations failed: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap gives the errors of the failed operations, so they can be inspected with errors.Is and errors.As
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Keys gives the keys of the failed operations
+func (e *MultiError) Keys() []string {
+	keys := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		keys[i] = err.Key
+	}
+	return keys
+}
+
+// Failed gives the operations which failed among ops, e.g. to retry them with another scheduler
+func (e *MultiError) Failed(ops []BulkOperation) []BulkOperation {
+	failed := make(map[string]bool, len(e.Errors))
+	for _, err := range e.Errors {
+		failed[err.Key] = true
+	}
+
+	var res []BulkOperation
+	for _, op := range ops {
+		if failed[op.Key] {
+			res = append(res, op)
+		}
+	}
+	return res
+}
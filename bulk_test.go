@@ -0,0 +1,155 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkSchedulerResume(t *testing.T) {
+	var calls []string
+	failing := true
+	op := func(key string) BulkOperation {
+		return BulkOperation{Key: key, Do: func() error {
+			if key == "b" && failing {
+				return errors.New("boom")
+			}
+			calls = append(calls, key)
+			return nil
+		}}
+	}
+	ops := []BulkOperation{op("a"), op("b"), op("c")}
+
+	s := NewBulkScheduler(60000)
+	var progress []int
+	s.Progress = ProgressFunc(func(done, total int) {
+		assert.Equal(t, 3, total)
+		progress = append(progress, done)
+	})
+
+	err := s.Run(context.Background(), ops)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a"}, calls)
+
+	// Resuming skips operations already performed
+	failing, progress = false, nil
+	assert.NoError(t, s.Run(context.Background(), ops))
+	assert.Equal(t, []string{"a", "b", "c"}, calls)
+	assert.Equal(t, []int{1, 2, 3}, progress)
+}
+
+func TestBulkSchedulerCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewBulkScheduler(60000)
+	err := s.Run(ctx, []BulkOperation{{Key: "a", Do: func() error { return nil }}})
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, s.Completed)
+}
+
+type recordingProgress struct {
+	events []string
+}
+
+func (p *recordingProgress) OnStart(total int) {
+	p.events = append(p.events, fmt.Sprintf("start %d", total))
+}
+
+func (p *recordingProgress) OnItem(key string, done, total int, err error) {
+	p.events = append(p.events, fmt.Sprintf("item %s %d/%d %v", key, done, total, err))
+}
+
+func (p *recordingProgress) OnDone(done, total int, err error) {
+	p.events = append(p.events, fmt.Sprintf("done %d/%d %v", done, total, err != nil))
+}
+
+func TestBulkSchedulerProgress(t *testing.T) {
+	p := &recordingProgress{}
+	s := NewBulkScheduler(60000)
+	s.Progress = p
+
+	err := s.Run(context.Background(), []BulkOperation{
+		{Key: "a", Do: func() error { return nil }},
+		{Key: "b", Do: func() error { return errors.New("boom") }},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"start 2",
+		"item a 1/2 <nil>",
+		"item b 1/2 boom",
+		"done 1/2 true",
+	}, p.events)
+}
+
+func TestBulkSchedulerCheckGuard(t *testing.T) {
+	client := newTestClient(t, "http://localhost")
+	client.MaxChecks = 2
+
+	called := false
+	s := NewBulkScheduler(60000)
+	s.Completed["a"] = true
+	s.CheckGuard = func(adding int) error {
+		assert.Equal(t, 2, adding)
+		return &CheckBudgetError{Current: 1, Adding: adding, Max: client.MaxChecks}
+	}
+
+	add := func() error { called = true; return nil }
+	err := s.Run(context.Background(), []BulkOperation{
+		{Key: "a", AddsCheck: true, Do: add},
+		{Key: "b", AddsCheck: true, Do: add},
+		{Key: "c", AddsCheck: true, Do: add},
+		{Key: "d", Do: add},
+	})
+
+	var budgetErr *CheckBudgetError
+	assert.ErrorAs(t, err, &budgetErr)
+	assert.EqualError(t, err, "adding 2 checks to the 1 existing ones would exceed the budget of 2 checks")
+	assert.False(t, called)
+
+	// Without budget, the guard does not hit the API
+	client.MaxChecks = 0
+	assert.NoError(t, client.Check.EnsureBudget(10))
+}
+
+func TestBulkSchedulerContinueOnError(t *testing.T) {
+	var calls []string
+	errBoom := errors.New("boom")
+	failing := map[string]bool{"b": true, "d": true}
+	op := func(key string) BulkOperation {
+		return BulkOperation{Key: key, Do: func() error {
+			calls = append(calls, key)
+			if failing[key] {
+				return errBoom
+			}
+			return nil
+		}}
+	}
+	ops := []BulkOperation{op("a"), op("b"), op("c"), op("d")}
+
+	s := NewBulkScheduler(60000)
+	s.ContinueOnError = true
+	err := s.Run(context.Background(), ops)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, calls)
+
+	var multi *MultiError
+	require.True(t, errors.As(err, &multi))
+	assert.Equal(t, []string{"b", "d"}, multi.Keys())
+	assert.ErrorIs(t, err, errBoom)
+	assert.EqualError(t, err, "2 bulk operations failed: bulk operation b: boom; bulk operation d: boom")
+
+	failed := multi.Failed(ops)
+	require.Len(t, failed, 2)
+	assert.Equal(t, "b", failed[0].Key)
+
+	// Running again only retries the failed operations
+	calls, failing["b"] = nil, false
+	err = s.Run(context.Background(), ops)
+	assert.Equal(t, []string{"b", "d"}, calls)
+	require.True(t, errors.As(err, &multi))
+	assert.Equal(t, []string{"d"}, multi.Keys())
+}
@@ -0,0 +1,118 @@
+package updown
+
+import (
+	"time"
+)
+
+// Window is a period of time
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// BusinessHours describes working hours, to compute availability during business hours only
+type BusinessHours struct {
+	// Time zone of the working hours, UTC when nil
+	Location *time.Location
+	// Working days
+	Days []time.Weekday
+	// Start and end of the working day as offsets from midnight, e.g. 9*time.Hour
+	Start time.Duration
+	End   time.Duration
+	// Days off, only their date in Location matters
+	Holidays []time.Time
+	// Periods excluded from working hours, e.g. maintenance windows imported with ParseICal
+	Closures []Window
+}
+
+// Windows lists the working periods between start and end
+func (b BusinessHours) Windows(start, end time.Time) []Window {
+	loc := b.location()
+	start, end = start.In(loc), end.In(loc)
+
+	var res []Window
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !b.isWorkingDay(day) {
+			continue
+		}
+
+		w := Window{Start: atOffset(day, b.Start), End: atOffset(day, b.End)}
+		if w.Start.Before(start) {
+			w.Start = start
+		}
+		if w.End.After(end) {
+			w.End = end
+		}
+		if w.End.After(w.Start) {
+			res = append(res, subtractWindows(w, b.Closures)...)
+		}
+	}
+	return res
+}
+
+// subtractWindows gives the parts of a window not covered by any of the closures
+func subtractWindows(w Window, closures []Window) []Window {
+	res := []Window{w}
+	for _, c := range closures {
+		var next []Window
+		for _, r := range res {
+			if !c.Start.Before(r.End) || !c.End.After(r.Start) {
+				next = append(next, r)
+				continue
+			}
+			if c.Start.After(r.Start) {
+				next = append(next, Window{Start: r.Start, End: c.Start})
+			}
+			if c.End.Before(r.End) {
+				next = append(next, Window{Start: c.End, End: r.End})
+			}
+		}
+		res = next
+	}
+	return res
+}
+
+func (b BusinessHours) location() *time.Location {
+	if b.Location == nil {
+		return time.UTC
+	}
+	return b.Location
+}
+
+func (b BusinessHours) isWorkingDay(day time.Time) bool {
+	for _, holiday := range b.Holidays {
+		holiday = holiday.In(b.location())
+		if holiday.Year() == day.Year() && holiday.YearDay() == day.YearDay() {
+			return false
+		}
+	}
+	for _, d := range b.Days {
+		if d == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// atOffset gives the wall clock time of a day at an offset from midnight, regardless of DST changes
+func atOffset(day time.Time, offset time.Duration) time.Time {
+	h, m, s := int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second)
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, day.Location())
+}
+
+// BusinessHoursUptime computes the uptime percentage during business hours over the calendar
+// month containing the given time, in the time zone of the business hours
+func BusinessHoursUptime(downtimes []Downtime, month time.Time, hours BusinessHours) float64 {
+	month = month.In(hours.location())
+	start, end := monthBounds(month, time.Now())
+
+	var total, down time.Duration
+	for _, w := range hours.Windows(start, end) {
+		total += w.End.Sub(w.Start)
+		down += downDuration(downtimes, w.Start, w.End)
+	}
+	if total == 0 {
+		return 100
+	}
+	return 100 * (1 - float64(down)/float64(total))
+}
@@ -0,0 +1,54 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+func TestBusinessHoursWindows(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+
+	hours := BusinessHours{
+		Location: paris,
+		Days:     weekdays,
+		Start:    9 * time.Hour,
+		End:      17*time.Hour + 30*time.Minute,
+		Holidays: []time.Time{time.Date(2024, time.April, 1, 0, 0, 0, 0, paris)},
+	}
+
+	// From Friday March 29th to Tuesday April 2nd, Easter Monday being off
+	windows := hours.Windows(
+		time.Date(2024, time.March, 29, 0, 0, 0, 0, paris),
+		time.Date(2024, time.April, 3, 0, 0, 0, 0, paris),
+	)
+	assert.Equal(t, []Window{
+		{time.Date(2024, time.March, 29, 9, 0, 0, 0, paris), time.Date(2024, time.March, 29, 17, 30, 0, 0, paris)},
+		{time.Date(2024, time.April, 2, 9, 0, 0, 0, paris), time.Date(2024, time.April, 2, 17, 30, 0, 0, paris)},
+	}, windows)
+}
+
+func TestBusinessHoursUptime(t *testing.T) {
+	hours := BusinessHours{Days: weekdays, Start: 9 * time.Hour, End: 17 * time.Hour}
+	month := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	// April 2024 has 22 working days of 8 hours, 176 hours
+	downtimes := []Downtime{
+		// At night, not counted
+		{StartedAt: "2024-04-02T01:00:00Z", EndedAt: "2024-04-02T05:00:00Z"},
+		// On a Saturday, not counted
+		{StartedAt: "2024-04-06T10:00:00Z", EndedAt: "2024-04-06T12:00:00Z"},
+		// 1.76 hours during business hours
+		{StartedAt: "2024-04-03T08:00:00Z", EndedAt: "2024-04-03T10:45:36Z"},
+	}
+
+	assert.InDelta(t, 99.0, BusinessHoursUptime(downtimes, month, hours), 0.0001)
+	// Wall-clock uptime counts all 8.76 hours over 720
+	assert.InDelta(t, 98.7833, MonthlyUptime(downtimes, month), 0.0001)
+}
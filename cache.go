@@ -1,7 +1,9 @@
 package updown
 
 import (
+	"container/list"
 	"sync"
+	"time"
 )
 
 // Cache lets you cache indefinitely values
@@ -44,3 +46,89 @@ func (c *MemoryCache) Get(key string) (has bool, value string) {
 func NewMemoryCache() *MemoryCache {
 	return &MemoryCache{items: make(map[string]string)}
 }
+
+// missCache remembers keys which could not be found, for a limited time
+type missCache struct {
+	items map[string]time.Time
+	mu    sync.Mutex
+}
+
+// missed tells if the key was missed less than ttl ago
+func (c *missCache) missed(key string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	at, has := c.items[key]
+	if has && time.Since(at) >= ttl {
+		delete(c.items, key)
+		has = false
+	}
+	return has
+}
+
+// miss records that the key could not be found
+func (c *missCache) miss(key string) {
+	c.mu.Lock()
+	c.items[key] = time.Now()
+	c.mu.Unlock()
+}
+
+// forget clears the misses of the given keys
+func (c *missCache) forget(keys ...string) {
+	c.mu.Lock()
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	c.mu.Unlock()
+}
+
+func newMissCache() *missCache {
+	return &missCache{items: make(map[string]time.Time)}
+}
+
+// lru keeps values by key, dropping the least recently used ones beyond a limit. It is not
+// safe for concurrent use
+type lru[V any] struct {
+	entries map[string]*list.Element
+	// Entries, the most recently used first
+	order list.List
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+// get gives the value of a key, which becomes the most recently used
+func (l *lru[V]) get(key string) (V, bool) {
+	e, has := l.entries[key]
+	if !has {
+		var zero V
+		return zero, false
+	}
+	l.order.MoveToFront(e)
+	return e.Value.(*lruEntry[V]).value, true
+}
+
+// put sets the value of a key, keeping at most limit values
+func (l *lru[V]) put(key string, value V, limit int) {
+	if l.entries == nil {
+		l.entries = make(map[string]*list.Element)
+	}
+	if e, has := l.entries[key]; has {
+		e.Value.(*lruEntry[V]).value = value
+		l.order.MoveToFront(e)
+	} else {
+		l.entries[key] = l.order.PushFront(&lruEntry[V]{key: key, value: value})
+	}
+	for l.order.Len() > max(limit, 0) {
+		l.remove(l.order.Back().Value.(*lruEntry[V]).key)
+	}
+}
+
+// remove drops the value of a key
+func (l *lru[V]) remove(key string) {
+	if e, has := l.entries[key]; has {
+		l.order.Remove(e)
+		delete(l.entries, key)
+	}
+}
@@ -1,6 +1,8 @@
 package updown
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +19,35 @@ func TestCache(t *testing.T) {
 	assert.True(t, has)
 	assert.Equal(t, "bar", val)
 }
+
+func TestTokenForAliasMisses(t *testing.T) {
+	var lists int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		w.Write([]byte(`[{"token":"abcd","alias":"existing"}]`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Check.TokenForAlias("missing")
+		assert.Equal(t, ErrTokenNotFound, err)
+	}
+	assert.Equal(t, 1, lists)
+
+	// Bypassing the cache of misses
+	_, err := client.Check.TokenForAliasFresh("missing")
+	assert.Equal(t, ErrTokenNotFound, err)
+	assert.Equal(t, 2, lists)
+
+	// Misses expire
+	client.AliasMissTTL = 0
+	_, err = client.Check.TokenForAlias("missing")
+	assert.Equal(t, ErrTokenNotFound, err)
+	assert.Equal(t, 3, lists)
+
+	token, err := client.Check.TokenForAlias("existing")
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", token)
+	assert.Equal(t, 3, lists)
+}
@@ -0,0 +1,69 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// DefaultCertificateThresholds warns about certificates expiring within 30, 14 and 7 days
+var DefaultCertificateThresholds = []time.Duration{30 * 24 * time.Hour, 14 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+// CertificateWarning reports a check whose SSL certificate expires soon
+type CertificateWarning struct {
+	Check     Check
+	ExpiresAt time.Time
+	// Smallest threshold the expiry falls within
+	Threshold time.Duration
+}
+
+// ExpiresIn gives the time left before the certificate expires at the given time, negative once expired
+func (w CertificateWarning) ExpiresIn(now time.Time) time.Duration {
+	return w.ExpiresAt.Sub(now)
+}
+
+// CertificateWarnings lists the checks whose certificate expires within one of the thresholds,
+// soonest first. Run it daily to warn about certificates before they expire
+func CertificateWarnings(checks []Check, thresholds []time.Duration, now time.Time) []CertificateWarning {
+	var res []CertificateWarning
+	for _, check := range checks {
+		expiresAt, err := time.Parse(time.RFC3339, check.SSL.ExpiresAt)
+		if err != nil {
+			continue
+		}
+
+		left := expiresAt.Sub(now)
+		var threshold time.Duration
+		for _, t := range thresholds {
+			if left <= t && (threshold == 0 || t < threshold) {
+				threshold = t
+			}
+		}
+		if threshold > 0 {
+			res = append(res, CertificateWarning{Check: check, ExpiresAt: expiresAt, Threshold: threshold})
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ExpiresAt.Before(res[j].ExpiresAt) })
+	return res
+}
+
+// CertificateWarnings lists the checks whose certificate expires within one of the thresholds,
+// DefaultCertificateThresholds when none are given
+func (s *CheckService) CertificateWarnings(thresholds ...time.Duration) ([]CertificateWarning, *http.Response, error) {
+	return s.CertificateWarningsCtx(context.Background(), thresholds...)
+}
+
+// CertificateWarningsCtx is like CertificateWarnings, with a context
+func (s *CheckService) CertificateWarningsCtx(ctx context.Context, thresholds ...time.Duration) ([]CertificateWarning, *http.Response, error) {
+	if len(thresholds) == 0 {
+		thresholds = DefaultCertificateThresholds
+	}
+
+	checks, resp, err := s.ListCtx(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	return CertificateWarnings(checks, thresholds, time.Now()), resp, nil
+}
@@ -0,0 +1,34 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateWarnings(t *testing.T) {
+	now := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	days := func(n int) string { return now.AddDate(0, 0, n).Format(time.RFC3339) }
+
+	checks := []Check{
+		{Token: "later", SSL: SSL{ExpiresAt: days(60)}},
+		{Token: "month", SSL: SSL{ExpiresAt: days(20)}},
+		{Token: "week", SSL: SSL{ExpiresAt: days(5)}},
+		{Token: "expired", SSL: SSL{ExpiresAt: days(-1)}},
+		{Token: "http"},
+	}
+
+	warnings := CertificateWarnings(checks, DefaultCertificateThresholds, now)
+	assert.Len(t, warnings, 3)
+
+	assert.Equal(t, "expired", warnings[0].Check.Token)
+	assert.Equal(t, 7*24*time.Hour, warnings[0].Threshold)
+	assert.Equal(t, -24*time.Hour, warnings[0].ExpiresIn(now))
+
+	assert.Equal(t, "week", warnings[1].Check.Token)
+	assert.Equal(t, 7*24*time.Hour, warnings[1].Threshold)
+
+	assert.Equal(t, "month", warnings[2].Check.Token)
+	assert.Equal(t, 30*24*time.Hour, warnings[2].Threshold)
+}
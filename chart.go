@@ -0,0 +1,87 @@
+package updown
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a line of block characters, e.g. "▁▂▅█▃", scaled between
+// the lowest and the highest value. Infinite and NaN values are rendered as spaces
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if isFinite(v) {
+			lowest, highest = math.Min(lowest, v), math.Max(highest, v)
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		if !isFinite(v) {
+			sb.WriteRune(' ')
+			continue
+		}
+		i := 0
+		if highest > lowest {
+			i = int((v - lowest) / (highest - lowest) * float64(len(sparks)-1))
+		}
+		sb.WriteRune(sparks[i])
+	}
+	return sb.String()
+}
+
+// BarChart renders labelled values as horizontal bars, the longest one being width characters.
+// Negative, infinite and NaN values get no bar
+func BarChart(labels []string, values []float64, width int) string {
+	highest, labelWidth := 0.0, 0
+	for i, v := range values {
+		if isFinite(v) {
+			highest = math.Max(highest, v)
+		}
+		if i < len(labels) {
+			labelWidth = max(labelWidth, len([]rune(labels[i])))
+		}
+	}
+
+	var sb strings.Builder
+	for i, v := range values {
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
+		}
+		n := 0
+		if highest > 0 && isFinite(v) && v > 0 {
+			n = int(math.Round(v / highest * float64(width)))
+		}
+		fmt.Fprintf(&sb, "%-*s %s %g\n", labelWidth, label, strings.Repeat("█", n), v)
+	}
+	return sb.String()
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// Series extracts a value from metrics grouped by time, in chronological order. For instance
+// the response time with func(m MetricItem) float64 { return float64(m.Timings.Total) }
+func (m Metrics) Series(value func(MetricItem) float64) []float64 {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	res := make([]float64, len(keys))
+	for i, key := range keys {
+		res[i] = value(m[key])
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package updown
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{5, 5, 5}))
+	assert.Equal(t, "▁▄█▁", Sparkline([]float64{100, 250, 400, 100}))
+
+	// Non-finite values are skipped
+	assert.Equal(t, "▁ █  ", Sparkline([]float64{100, math.Inf(1), 400, math.Inf(-1), math.NaN()}))
+	assert.Equal(t, "  ", Sparkline([]float64{math.NaN(), math.Inf(1)}))
+}
+
+func TestBarChart(t *testing.T) {
+	chart := BarChart([]string{"Paris", "NYC"}, []float64{50, 100}, 10)
+	assert.Equal(t, "Paris █████ 50\nNYC   ██████████ 100\n", chart)
+
+	// Negative and non-finite values have no bar, e.g. host deltas below the mean
+	chart = BarChart([]string{"a", "b", "c", "d"}, []float64{-20, 10, math.NaN(), math.Inf(1)}, 4)
+	assert.Equal(t, "a  -20\nb ████ 10\nc  NaN\nd  +Inf\n", chart)
+}
+
+func TestMetricsSeries(t *testing.T) {
+	metrics := Metrics{
+		"2024-04-01T11:00:00Z": {Timings: Timings{Total: 200}},
+		"2024-04-01T10:00:00Z": {Timings: Timings{Total: 100}},
+	}
+	series := metrics.Series(func(m MetricItem) float64 { return float64(m.Timings.Total) })
+	assert.Equal(t, []float64{100, 200}, series)
+}
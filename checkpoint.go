@@ -0,0 +1,89 @@
+package updown
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Checkpoint persists the progress of bulk operations, so an interrupted run can be
+// resumed where it left off
+type Checkpoint interface {
+	// Load gives the keys of the operations already performed
+	Load() (map[string]bool, error)
+	// Save records the keys of the operations already performed
+	Save(completed map[string]bool) error
+}
+
+// FileCheckpoint is a checkpoint stored as a JSON file
+type FileCheckpoint struct {
+	Path string
+}
+
+// NewFileCheckpoint creates a checkpoint stored in the file at the given path
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{Path: path}
+}
+
+// Load reads the completed keys from the file. A missing file means nothing was done yet
+func (c *FileCheckpoint) Load() (map[string]bool, error) {
+	data, err := os.ReadFile(c.Path)
+	if os.IsNotExist(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+
+	completed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		completed[key] = true
+	}
+	return completed, nil
+}
+
+// Save writes the completed keys to the file, replacing it atomically
+func (c *FileCheckpoint) Save(completed map[string]bool) error {
+	keys := make([]string, 0, len(completed))
+	for key, done := range completed {
+		if done {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+// Clear removes the checkpoint, so the next run starts from scratch
+func (c *FileCheckpoint) Clear() error {
+	err := os.Remove(c.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
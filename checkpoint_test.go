@@ -0,0 +1,51 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	c := NewFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"))
+
+	completed, err := c.Load()
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+
+	require.NoError(t, c.Save(map[string]bool{"a": true, "b": true}))
+	completed, err = c.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true, "b": true}, completed)
+
+	require.NoError(t, c.Clear())
+	require.NoError(t, c.Clear())
+	completed, err = c.Load()
+	require.NoError(t, err)
+	assert.Empty(t, completed)
+}
+
+func TestBulkSchedulerCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	var calls []string
+	ops := []BulkOperation{
+		{Key: "a", Do: func() error { calls = append(calls, "a"); return nil }},
+		{Key: "b", Do: func() error { return errors.New("interrupted") }},
+	}
+
+	s := NewBulkScheduler(60000)
+	s.Checkpoint = NewFileCheckpoint(path)
+	assert.Error(t, s.Run(context.Background(), ops))
+
+	// A new process resumes from the checkpoint
+	ops[1].Do = func() error { calls = append(calls, "b"); return nil }
+	s = NewBulkScheduler(60000)
+	s.Checkpoint = NewFileCheckpoint(path)
+	assert.NoError(t, s.Run(context.Background(), ops))
+	assert.Equal(t, []string{"a", "b"}, calls)
+}
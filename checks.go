@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,9 +10,10 @@ import (
 
 // SSL represents the SSL section of a check
 type SSL struct {
-	TestedAt string `json:"tested_at,omitempty"`
-	Valid    bool   `json:"valid,omitempty"`
-	Error    string `json:"error,omitempty"`
+	TestedAt  string `json:"tested_at,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+	Valid     bool   `json:"valid,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
 // Check represents a check performed by Updown on a regular basis
@@ -78,24 +80,51 @@ type CheckItem struct {
 type CheckService struct {
 	client *Client
 	cache  Cache
+	misses *missCache
 }
 
 type removeResponse struct {
 	Deleted bool `json:"deleted,omitempty"`
 }
 
+// DefaultAliasMissTTL is how long aliases which could not be found are remembered by default
+const DefaultAliasMissTTL = 10 * time.Second
+
 // ErrTokenNotFound indicates that we cannot find a token for the given name
 var ErrTokenNotFound = errors.New("Could not determine a token for the given name")
 
 // TokenForAlias finds the Updown token for a check's alias
 func (s *CheckService) TokenForAlias(name string) (string, error) {
+	return s.TokenForAliasCtx(context.Background(), name)
+}
+
+// TokenForAliasCtx is like TokenForAlias, with a context
+func (s *CheckService) TokenForAliasCtx(ctx context.Context, name string) (string, error) {
+	return s.tokenForAlias(ctx, name, false)
+}
+
+// TokenForAliasFresh is like TokenForAlias, but lists the checks again if the alias
+// could not be found recently. See Client.AliasMissTTL
+func (s *CheckService) TokenForAliasFresh(name string) (string, error) {
+	return s.TokenForAliasFreshCtx(context.Background(), name)
+}
+
+// TokenForAliasFreshCtx is like TokenForAliasFresh, with a context
+func (s *CheckService) TokenForAliasFreshCtx(ctx context.Context, name string) (string, error) {
+	return s.tokenForAlias(ctx, name, true)
+}
+
+func (s *CheckService) tokenForAlias(ctx context.Context, name string, fresh bool) (string, error) {
 	// Retrieve from cache
 	if has, val := s.cache.Get(name); has {
 		return val, nil
 	}
+	if !fresh && s.misses != nil && s.misses.missed(name, s.client.AliasMissTTL) {
+		return "", ErrTokenNotFound
+	}
 
 	// List all checks
-	checks, _, err := s.List()
+	checks, _, err := s.ListCtx(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -114,48 +143,57 @@ func (s *CheckService) TokenForAlias(name string) (string, error) {
 	}
 
 	// Could not find a match
+	if s.misses != nil {
+		s.misses.miss(name)
+	}
 	return "", ErrTokenNotFound
 }
 
 // List lists all the checks
 func (s *CheckService) List() ([]Check, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "checks", nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return s.ListCtx(context.Background())
+}
 
-	var res []Check
-	resp, err := s.client.Do(req, &res)
+// ListCtx is like List, with a context
+func (s *CheckService) ListCtx(ctx context.Context) ([]Check, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "GET", "checks", nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return res, resp, err
+	return do[[]Check](s.client, req)
 }
 
 // Get gets a single check by its token
 func (s *CheckService) Get(token string) (Check, *http.Response, error) {
+	return s.GetCtx(context.Background(), token)
+}
+
+// GetCtx is like Get, with a context
+func (s *CheckService) GetCtx(ctx context.Context, token string) (Check, *http.Response, error) {
 	path := pathForToken(token)
 	if s.client.SkipCache {
 		path = fmt.Sprintf("%s?_=%d", path, time.Now().UnixNano())
 	}
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequestCtx(ctx, "GET", path, nil)
 	if err != nil {
 		return Check{}, nil, err
 	}
 
-	var res Check
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return Check{}, resp, err
-	}
-
-	return res, resp, err
+	return do[Check](s.client, req)
 }
 
 // Add adds a new check you want to be performed
 func (s *CheckService) Add(data CheckItem) (Check, *http.Response, error) {
-	req, err := s.client.NewRequest("POST", "checks", data)
+	return s.AddCtx(context.Background(), data)
+}
+
+// AddCtx is like Add, with a context
+func (s *CheckService) AddCtx(ctx context.Context, data CheckItem) (Check, *http.Response, error) {
+	if err := s.guardURL(ctx, data.URL); err != nil {
+		return Check{}, nil, err
+	}
+	req, err := s.client.NewRequestCtx(ctx, "POST", "checks", data)
 	if err != nil {
 		return Check{}, nil, err
 	}
@@ -165,29 +203,39 @@ func (s *CheckService) Add(data CheckItem) (Check, *http.Response, error) {
 	if err != nil {
 		return Check{}, resp, err
 	}
+	if s.misses != nil {
+		s.misses.forget(data.Alias, res.Alias)
+	}
 
 	return res, resp, err
 }
 
 // Update updates a check performed by Updown
 func (s *CheckService) Update(token string, data CheckItem) (Check, *http.Response, error) {
-	req, err := s.client.NewRequest("PUT", pathForToken(token), data)
-	if err != nil {
+	return s.UpdateCtx(context.Background(), token, data)
+}
+
+// UpdateCtx is like Update, with a context
+func (s *CheckService) UpdateCtx(ctx context.Context, token string, data CheckItem) (Check, *http.Response, error) {
+	if err := s.guardURL(ctx, data.URL); err != nil {
 		return Check{}, nil, err
 	}
-
-	var res Check
-	resp, err := s.client.Do(req, &res)
+	req, err := s.client.NewRequestCtx(ctx, "PUT", pathForToken(token), data)
 	if err != nil {
-		return Check{}, resp, err
+		return Check{}, nil, err
 	}
 
-	return res, resp, err
+	return do[Check](s.client, req)
 }
 
 // Remove removes a check from Updown by its token
 func (s *CheckService) Remove(token string) (bool, *http.Response, error) {
-	req, err := s.client.NewRequest("DELETE", pathForToken(token), nil)
+	return s.RemoveCtx(context.Background(), token)
+}
+
+// RemoveCtx is like Remove, with a context
+func (s *CheckService) RemoveCtx(ctx context.Context, token string) (bool, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "DELETE", pathForToken(token), nil)
 	if err != nil {
 		return false, nil, err
 	}
@@ -201,6 +249,14 @@ func (s *CheckService) Remove(token string) (bool, *http.Response, error) {
 	return res.Deleted, resp, err
 }
 
+// guardURL verifies the URL of a check when a URL guard is set
+func (s *CheckService) guardURL(ctx context.Context, checkURL string) error {
+	if s.client.urlGuard == nil || checkURL == "" {
+		return nil
+	}
+	return s.client.urlGuard.Check(ctx, checkURL)
+}
+
 func pathForToken(token string) string {
 	return fmt.Sprintf("checks/%s", token)
 }
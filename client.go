@@ -2,12 +2,16 @@ package updown
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 const (
@@ -19,16 +23,38 @@ const (
 
 // An ErrorResponse reports the error caused by an API request
 type ErrorResponse struct {
-	// HTTP response that caused this error
+	// HTTP response that caused this error. Its body was already read, see Body
 	Response *http.Response
 
-	// Error message
+	// HTTP status code of the response
+	StatusCode int
+
+	// Error message, from the error field of the body when it holds one, else the raw body
 	Message string
+
+	// Raw body of the response
+	Body []byte
+
+	// Messages about invalid parameters, for 422 responses
+	ValidationErrors []ValidationError
+
+	// ID of the request, see RequestIDHeader
+	RequestID string
+
+	// Attempts made before giving up, when returned by Client.Do
+	Attempts RetryInfo
 }
 
 func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
+	msg := fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message)
+	if r.Attempts.Attempts > 1 {
+		msg += ", after " + r.Attempts.String()
+	}
+	if r.RequestID != "" {
+		msg += fmt.Sprintf(" (request %s)", r.RequestID)
+	}
+	return msg
 }
 
 // Client manages communication the API
@@ -36,7 +62,7 @@ type Client struct {
 	// HTTP client used to communicate with the API
 	client *http.Client
 
-	// Base URL for API requests
+	// Base URL for API requests, with a trailing slash. See WithBaseURL
 	BaseURL *url.URL
 
 	// User agent for client
@@ -49,6 +75,70 @@ type Client struct {
 	// to bypass the API's 30-second cache
 	SkipCache bool
 
+	// ServeStale serves the last successful response of GET requests when the API
+	// cannot be reached or fails with a 5xx status. Such responses are flagged with
+	// the StaleHeader header, see StaleSince
+	ServeStale bool
+
+	// MaxStaleEntries is how many responses are kept for ServeStale, the least recently
+	// used ones being dropped first. Zero keeps none. See DefaultMaxStaleEntries
+	MaxStaleEntries int
+
+	// AliasMissTTL is how long Check.TokenForAlias remembers aliases which could not be found,
+	// not to list every check again on each lookup. Zero disables it. See DefaultAliasMissTTL
+	AliasMissTTL time.Duration
+
+	// MaxChecks is a budget of checks for the account, enforced by Check.EnsureBudget.
+	// Zero means no budget
+	MaxChecks int
+
+	// Timeout of requests, DefaultTimeout by default
+	timeout time.Duration
+
+	// Retry policy for failed requests, no retries by default
+	retry RetryPolicy
+
+	// Recorder of the mutating requests not sent in dry-run mode, see WithDryRun
+	dryRun *DryRunRecorder
+
+	// Refuse mutating requests, see WithReadOnly
+	readOnly bool
+
+	// Cache of GET responses and how long they are kept, see WithResponseCache
+	responseCache    ResponseCache
+	responseCacheTTL time.Duration
+
+	// Responses cached by ETag, see WithETagCache
+	etags *etagCache
+
+	// Keep the raw JSON body of responses readable, see WithRawJSON
+	keepRaw bool
+
+	// Guard rejecting checks of private hosts, none by default
+	urlGuard *URLGuard
+
+	// Headers sent with every request, see WithHeader
+	headers http.Header
+
+	// Suffix appended to the User-Agent header, see WithUserAgentSuffix
+	userAgentSuffix string
+
+	// Logger API calls are logged to, none by default
+	logger *slog.Logger
+
+	// Writer requests and responses are dumped to, see WithDebug
+	debug *debugWriter
+
+	// Circuit breaker failing fast after repeated failures, none by default
+	breaker *circuitBreaker
+
+	// Maximum time spent waiting for rate limited requests to be retried, see WithRateLimitRetry
+	rateLimitBudget time.Duration
+
+	// State shared with the clients derived by WithDefaults
+	stale *staleCache
+	rate  *rateLimitState
+
 	// Services used for communications with the API
 	Check      CheckService
 	Downtime   DowntimeService
@@ -59,21 +149,30 @@ type Client struct {
 	StatusPage StatusPageService
 }
 
-// NewClient returns a new API client.
-func NewClient(apiKey string, httpClient *http.Client) *Client {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
-
+// NewClient returns a new API client, configured by the given options
+func NewClient(apiKey string, opts ...Option) (*Client, error) {
 	baseURL, _ := url.Parse(defaultBaseURL)
 
 	c := &Client{
-		client:    httpClient,
+		client:    NewHTTPClient(TransportConfig{}),
 		BaseURL:   baseURL,
 		UserAgent: userAgent,
 		APIKey:    apiKey,
+
+		AliasMissTTL:    DefaultAliasMissTTL,
+		MaxStaleEntries: DefaultMaxStaleEntries,
+		timeout:         DefaultTimeout,
+
+		stale: &staleCache{},
+		rate:  &rateLimitState{},
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
 	}
-	c.Check = CheckService{client: c, cache: NewMemoryCache()}
+
+	c.Check = CheckService{client: c, cache: NewMemoryCache(), misses: newMissCache()}
 	c.Downtime = DowntimeService{client: c}
 	c.Metric = MetricService{client: c}
 	c.Node = NodeService{client: c}
@@ -81,13 +180,23 @@ func NewClient(apiKey string, httpClient *http.Client) *Client {
 	c.Recipient = RecipientService{client: c}
 	c.StatusPage = StatusPageService{client: c}
 
-	return c
+	return c, nil
 }
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash.
 // If specified, the value pointed to by body is JSON encoded and included in as the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestCtx(context.Background(), method, urlStr, body)
+}
+
+// NewRequestCtx is like NewRequest, with a context. Do stops waiting for the API once the
+// context is cancelled or its deadline exceeded
+func (c *Client) NewRequestCtx(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("updown: BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -95,23 +204,26 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 	u := c.BaseURL.ResolveReference(rel)
 
-	buf := new(bytes.Buffer)
+	var reader io.Reader = http.NoBody
 	if body != nil {
-		err := json.NewEncoder(buf).Encode(body)
+		data, err := encodeJSON(body)
 		if err != nil {
 			return nil, err
 		}
+		reader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
-	req.Header.Add("User-Agent", userAgent)
+	req.Header.Add("User-Agent", c.UserAgent)
 	req.Header.Add("X-API-KEY", c.APIKey)
+	c.setHeaders(req)
+	setRequestID(req)
 	return req, nil
 }
 
@@ -119,52 +231,173 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	response, err := c.client.Do(req)
+	if c.readOnly && isMutating(req) {
+		return nil, ErrReadOnly
+	}
+	if c.dryRun != nil && isMutating(req) {
+		response, err := c.dryRunResponse(req)
+		if err != nil {
+			return nil, err
+		}
+		return response, decodeResponse(response, v)
+	}
+
+	// Cached responses do not reach the API, so they neither need nor count towards the circuit breaker
+	if c.cachesResponse(req) {
+		if cached, ok := c.cachedResponse(req); ok {
+			return cached, c.decode(req, cached, v)
+		}
+	}
+
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+	if c.etags != nil && !isLive(req) {
+		c.etags.prepare(req)
+	}
+
+	response, attempts, err := c.send(req)
+	if c.logger != nil {
+		c.logCall(req, response, err, attempts)
+	}
+	if c.breaker != nil {
+		cancelled := req.Context().Err() != nil
+		failed := !cancelled && isTransient(response, err)
+		c.breaker.record(!cancelled && !failed, failed)
+	}
 	if err != nil {
-		return nil, err
+		// Report a cancelled or expired context rather than the resulting network error
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if stale, ok := c.staleResponse(req); ok {
+			return stale, c.decode(req, stale, v)
+		}
+		return nil, &NetworkError{Err: err, RequestID: req.Header.Get(RequestIDHeader), Attempts: attempts}
+	}
+
+	c.recordRateLimit(response)
+	if c.etags != nil {
+		response, _ = c.etags.notModified(response)
 	}
 
+	// The body may be replaced to be read again, see WithRawJSON
+	body := response.Body
 	defer func() {
-		if rerr := response.Body.Close(); err == nil {
+		if rerr := body.Close(); err == nil {
 			err = rerr
 		}
 	}()
 
 	err = CheckResponse(response)
 	if err != nil {
+		if errResp, ok := err.(*ErrorResponse); ok {
+			errResp.Attempts = attempts
+		}
+		if response.StatusCode >= 500 {
+			if stale, ok := c.staleResponse(req); ok {
+				return stale, c.decode(req, stale, v)
+			}
+		}
 		return response, err
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err := io.Copy(w, response.Body)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			err := json.NewDecoder(response.Body).Decode(v)
-			if err != nil {
-				return nil, err
-			}
+	if c.ServeStale && req.Method == "GET" {
+		if err := c.stale.store(response, c.MaxStaleEntries); err != nil {
+			return nil, err
+		}
+	}
+	if c.etags != nil {
+		if err := c.etags.store(response); err != nil {
+			return nil, err
 		}
 	}
+	if c.cachesResponse(req) {
+		if err := c.cacheResponse(response); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.decode(req, response, v); err != nil {
+		return nil, err
+	}
 
 	return response, err
 }
 
+// staleResponse gives the last successful response to a GET request, if serving stale data is enabled
+func (c *Client) staleResponse(req *http.Request) (*http.Response, bool) {
+	if !c.ServeStale || req.Method != "GET" || isLive(req) {
+		return nil, false
+	}
+	return c.stale.response(req)
+}
+
+// decodeResponse decodes the body of a response into v, or copies it when v is an io.Writer
+func decodeResponse(response *http.Response, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if s, ok := v.(streamDecoder); ok {
+		return s.decodeFrom(response.Body)
+	}
+	if w, ok := v.(io.Writer); ok {
+		if _, err := io.Copy(w, response.Body); err != nil {
+			return fmt.Errorf("updown: reading response: %w", err)
+		}
+		return nil
+	}
+
+	// Large bodies, or bodies of unknown size, are decoded as they are read rather than held in memory
+	if response.ContentLength < 0 || response.ContentLength > maxPooledBuffer {
+		if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+			return fmt.Errorf("updown: decoding response: %w", err)
+		}
+		return nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(response.Body); err != nil {
+		return fmt.Errorf("updown: reading response: %w", err)
+	}
+	if err := unmarshalJSON(buf.Bytes(), v); err != nil {
+		return fmt.Errorf("updown: decoding response: %w", err)
+	}
+	return nil
+}
+
 // CheckResponse checks the API response for errors, and returns them if present. A response is considered an
 // error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
+// body, or a JSON response body with an error message. Any other response body is used as the message.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r, StatusCode: r.StatusCode, RequestID: responseRequestID(r)}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
-		// Try to unmarshal into ErrorResponse first
-		_ = json.Unmarshal(data, errorResponse)
+		errorResponse.Body = data
+
+		// Try to find the message of the API first
+		var body struct {
+			Error   string `json:"error"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &body)
+		errorResponse.Message = body.Error
+		if errorResponse.Message == "" {
+			errorResponse.Message = body.Message
+		}
+		if r.StatusCode == http.StatusUnprocessableEntity {
+			errorResponse.ValidationErrors = parseValidationErrors(data)
+			if errorResponse.Message == "" && len(errorResponse.ValidationErrors) > 0 {
+				errorResponse.Message = joinValidationErrors(errorResponse.ValidationErrors)
+			}
+		}
 		// If Message is still empty, use the raw response body
 		if errorResponse.Message == "" {
 			errorResponse.Message = string(data)
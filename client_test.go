@@ -1,10 +1,10 @@
 package updown
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
-	"os"
 	"testing"
 	"time"
 
@@ -22,11 +22,18 @@ const (
 )
 
 func newClient() *Client {
-	apiKey := os.Getenv("UPDOWN_API_KEY")
-	if apiKey == "" {
-		panic("API key is not set. Set UPDOWN_API_KEY environment variable.")
+	client, err := NewClientFromEnv()
+	if err != nil {
+		panic(err)
 	}
-	return NewClient(apiKey, nil)
+	return client
+}
+
+// newTestClient creates a client for a test server
+func newTestClient(t *testing.T, baseURL string, opts ...Option) *Client {
+	client, err := NewClient("key", append([]Option{WithBaseURL(baseURL + "/")}, opts...)...)
+	require.NoError(t, err)
+	return client
 }
 
 // createTestCheck creates a check for testing and returns its token
@@ -116,8 +123,9 @@ func TestGet(t *testing.T) {
 	// Test with invalid token
 	_, resp, err = client.Check.Get("aaaaaa")
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "404")
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusNotFound, errResp.StatusCode)
 }
 
 func TestListDowntimes(t *testing.T) {
@@ -0,0 +1,111 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServiceStatus is the overall status of a composite service
+type ServiceStatus string
+
+const (
+	StatusOperational ServiceStatus = "operational"
+	StatusDegraded    ServiceStatus = "degraded"
+	StatusDown        ServiceStatus = "down"
+)
+
+// ServiceComponent is a check contributing to the status of a composite service
+type ServiceComponent struct {
+	Token string
+	// Weight of the check in the service, 1 when zero
+	Weight float64
+}
+
+// CompositeService rolls several checks up into a single status, e.g. for custom status pages.
+// The status depends on the share of the total weight of the checks which are down
+type CompositeService struct {
+	Name       string
+	Components []ServiceComponent
+	// Share of weight down from which the service is degraded. Zero means any check being down
+	DegradedAt float64
+	// Share of weight down from which the service is down. Zero means every check being down
+	DownAt float64
+}
+
+// ServiceHealth is the status of a composite service computed from its checks
+type ServiceHealth struct {
+	Service string
+	Status  ServiceStatus
+	// Share of the weight of the checks which are down, between 0 and 1
+	DownShare float64
+	// Tokens of the checks which are down
+	Down []string
+	// Tokens of the checks which could not be found, counted as down
+	Missing []string
+}
+
+// Evaluate computes the status of the service from the current state of the checks. Disabled
+// checks are ignored
+func (s CompositeService) Evaluate(checks []Check) ServiceHealth {
+	res := ServiceHealth{Service: s.Name, Status: StatusOperational}
+	byToken := make(map[string]Check, len(checks))
+	for _, check := range checks {
+		byToken[check.Token] = check
+	}
+
+	var total, down float64
+	for _, component := range s.Components {
+		weight := component.Weight
+		if weight == 0 {
+			weight = 1
+		}
+
+		check, found := byToken[component.Token]
+		switch {
+		case !found:
+			res.Missing = append(res.Missing, component.Token)
+			down += weight
+		case !check.Enabled:
+			continue
+		case check.Down:
+			res.Down = append(res.Down, component.Token)
+			down += weight
+		}
+		total += weight
+	}
+	if total == 0 || down == 0 {
+		return res
+	}
+
+	res.DownShare = down / total
+	downAt := s.DownAt
+	if downAt <= 0 {
+		downAt = 1
+	}
+	switch {
+	case res.DownShare >= downAt:
+		res.Status = StatusDown
+	case res.DownShare >= s.DegradedAt:
+		res.Status = StatusDegraded
+	}
+	return res
+}
+
+// ServiceHealth computes the status of composite services from the current state of their checks
+func (s *CheckService) ServiceHealth(services ...CompositeService) ([]ServiceHealth, *http.Response, error) {
+	return s.ServiceHealthCtx(context.Background(), services...)
+}
+
+// ServiceHealthCtx is like ServiceHealth, with a context
+func (s *CheckService) ServiceHealthCtx(ctx context.Context, services ...CompositeService) ([]ServiceHealth, *http.Response, error) {
+	checks, resp, err := s.ListCtx(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	res := make([]ServiceHealth, len(services))
+	for i, service := range services {
+		res[i] = service.Evaluate(checks)
+	}
+	return res, resp, nil
+}
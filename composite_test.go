@@ -0,0 +1,61 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeServiceEvaluate(t *testing.T) {
+	checks := []Check{
+		{Token: "web1", Enabled: true},
+		{Token: "web2", Enabled: true, Down: true},
+		{Token: "db", Enabled: true},
+		{Token: "old", Enabled: false, Down: true},
+	}
+	service := CompositeService{
+		Name: "Shop",
+		Components: []ServiceComponent{
+			{Token: "web1"},
+			{Token: "web2"},
+			{Token: "db", Weight: 2},
+			{Token: "old"},
+		},
+	}
+
+	res := service.Evaluate(checks)
+	assert.Equal(t, StatusDegraded, res.Status)
+	assert.Equal(t, 0.25, res.DownShare)
+	assert.Equal(t, []string{"web2"}, res.Down)
+
+	// Below the degraded threshold
+	service.DegradedAt = 0.5
+	assert.Equal(t, StatusOperational, service.Evaluate(checks).Status)
+
+	// Missing checks count as down
+	service.DownAt = 0.5
+	service.Components = append(service.Components, ServiceComponent{Token: "gone", Weight: 4})
+	res = service.Evaluate(checks)
+	assert.Equal(t, StatusDown, res.Status)
+	assert.Equal(t, []string{"gone"}, res.Missing)
+
+	checks[1].Down = false
+	res = CompositeService{Name: "Shop", Components: []ServiceComponent{{Token: "web1"}, {Token: "web2"}}}.Evaluate(checks)
+	assert.Equal(t, ServiceHealth{Service: "Shop", Status: StatusOperational}, res)
+}
+
+func TestServiceHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"token":"api","enabled":true,"down":true}]`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	res, _, err := client.Check.ServiceHealth(CompositeService{Name: "API", Components: []ServiceComponent{{Token: "api"}}})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, StatusDown, res[0].Status)
+}
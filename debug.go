@@ -0,0 +1,49 @@
+package updown
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// WithDebug dumps every request sent to the API and every response received to w, with the
+// API key and the passwords of check URLs redacted. Each attempt is dumped when requests are retried
+func WithDebug(w io.Writer) Option {
+	return func(c *Client) error {
+		c.debug = &debugWriter{w: w}
+		return nil
+	}
+}
+
+type debugWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (d *debugWriter) dumpRequest(req *http.Request, apiKey string) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	d.write("request", dump, err, apiKey)
+}
+
+func (d *debugWriter) dumpResponse(resp *http.Response, apiKey string) {
+	dump, err := httputil.DumpResponse(resp, true)
+	d.write("response", dump, err, apiKey)
+}
+
+func (d *debugWriter) write(kind string, dump []byte, err error, apiKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(d.w, "---[ %s ]---\ncould not dump: %v\n\n", kind, err)
+		return
+	}
+	if apiKey != "" {
+		dump = bytes.ReplaceAll(dump, []byte(apiKey), []byte("REDACTED"))
+	}
+	dump = urlPassword.ReplaceAll(dump, []byte("${1}"+RedactedPassword+"@"))
+	fmt.Fprintf(d.w, "---[ %s ]---\n%s\n\n", kind, bytes.TrimRight(dump, "\r\n"))
+}
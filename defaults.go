@@ -0,0 +1,47 @@
+package updown
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrReadOnly indicates that a request adding, updating or removing resources was refused by a
+// read-only client, see WithReadOnly
+var ErrReadOnly = errors.New("Mutating request refused by a read-only client")
+
+// WithReadOnly refuses the requests adding, updating or removing resources with ErrReadOnly,
+// without sending them. Unlike WithDryRun, callers are told that nothing was changed
+func WithReadOnly() Option {
+	return func(c *Client) error {
+		c.readOnly = true
+		return nil
+	}
+}
+
+// isMutating tells if a request may add, update or remove resources
+func isMutating(req *http.Request) bool {
+	return req.Method != "GET" && req.Method != "HEAD"
+}
+
+// WithDefaults derives a client with other defaults, such as the timeout, the retry policy or
+// the read-only mode, e.g. for the batch jobs of an application also serving interactive
+// requests. The derived client shares the HTTP client, the caches, the circuit breaker and
+// the rate limit state of c, unless the options replace them, and c is left untouched
+func (c *Client) WithDefaults(opts ...Option) (*Client, error) {
+	d := *c
+	d.headers = c.headers.Clone()
+	for _, opt := range opts {
+		if err := opt(&d); err != nil {
+			return nil, err
+		}
+	}
+
+	d.Check = CheckService{client: &d, cache: c.Check.cache, misses: c.Check.misses}
+	d.Downtime = DowntimeService{client: &d}
+	d.Metric = MetricService{client: &d}
+	d.Node = NodeService{client: &d}
+	d.Webhook = WebhookService{client: &d}
+	d.Recipient = RecipientService{client: &d}
+	d.StatusPage = StatusPageService{client: &d}
+	return &d, nil
+}
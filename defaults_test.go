@@ -0,0 +1,68 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReadOnly(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, WithReadOnly())
+	_, _, err := client.Check.List()
+	require.NoError(t, err)
+	_, _, err = client.Check.Remove("abcd")
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithDefaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Write([]byte(`[{"token":"abcd","alias":"Example"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, WithHeader("X-Team", "web"))
+	batch, err := client.WithDefaults(WithTimeout(5*time.Minute), WithRetryPolicy(DefaultRetryPolicy), WithReadOnly(), WithHeader("X-Job", "nightly"))
+	require.NoError(t, err)
+
+	// The derived client has its own defaults
+	assert.Equal(t, 5*time.Minute, batch.timeout)
+	assert.Equal(t, DefaultRetryPolicy, batch.retry)
+	assert.True(t, batch.readOnly)
+	assert.Equal(t, http.Header{"X-Team": {"web"}, "X-Job": {"nightly"}}, batch.headers)
+	assert.Same(t, batch, batch.Check.client)
+	assert.Same(t, batch, batch.StatusPage.client)
+
+	// While the original one is left untouched
+	assert.Equal(t, DefaultTimeout, client.timeout)
+	assert.False(t, client.readOnly)
+	assert.Equal(t, http.Header{"X-Team": {"web"}}, client.headers)
+	assert.Same(t, client, client.Check.client)
+
+	// The HTTP client, caches and rate limit are shared
+	assert.Same(t, client.client, batch.client)
+	token, err := batch.Check.TokenForAlias("Example")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", token)
+	has, _ := client.Check.cache.Get("Example")
+	assert.True(t, has)
+	rl, ok := client.RateLimit()
+	require.True(t, ok)
+	assert.Equal(t, 42, rl.Remaining)
+
+	_, err = client.WithDefaults(WithTimeout(-time.Second))
+	assert.Error(t, err)
+}
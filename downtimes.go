@@ -1,9 +1,11 @@
 package updown
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 // Downtime represents a downtime period for a check
@@ -12,6 +14,8 @@ type Downtime struct {
 	StartedAt string `json:"started_at,omitempty"`
 	EndedAt   string `json:"ended_at,omitempty"`
 	Duration  int    `json:"duration,omitempty"`
+	// Partial downtimes were only seen from some monitoring locations
+	Partial bool `json:"partial,omitempty"`
 }
 
 // DowntimeService interacts with the downtimes section of the API
@@ -21,19 +25,62 @@ type DowntimeService struct {
 
 // List lists all known downtimes for a check
 func (s *DowntimeService) List(token string, pageNb int) ([]Downtime, *http.Response, error) {
+	return s.ListCtx(context.Background(), token, pageNb)
+}
+
+// ListCtx is like List, with a context
+func (s *DowntimeService) ListCtx(ctx context.Context, token string, pageNb int) ([]Downtime, *http.Response, error) {
 	path := fmt.Sprintf("checks/%s/downtimes?page=%s", token, strconv.Itoa(max(1, pageNb)))
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequestCtx(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return do[[]Downtime](s.client, req)
+}
+
+// ListSince lists the downtimes of a check which ended after the given time,
+// going through as many pages as needed
+func (s *DowntimeService) ListSince(token string, since time.Time) ([]Downtime, *http.Response, error) {
+	return s.ListSinceCtx(context.Background(), token, since)
+}
+
+// ListSinceCtx is like ListSince, with a context
+func (s *DowntimeService) ListSinceCtx(ctx context.Context, token string, since time.Time) ([]Downtime, *http.Response, error) {
 	var res []Downtime
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return nil, resp, err
+	for page := 1; ; page++ {
+		downtimes, resp, err := s.ListCtx(ctx, token, page)
+		if err != nil {
+			return nil, resp, err
+		}
+		if len(downtimes) == 0 {
+			return res, resp, nil
+		}
+
+		for _, d := range downtimes {
+			if _, to, ok := downtimeBounds(d); ok && to.Before(since) {
+				// Downtimes are listed from the most recent one
+				return res, resp, nil
+			}
+			res = append(res, d)
+		}
 	}
+}
 
-	return res, resp, err
+// downtimeBounds parses the start and end of a downtime, ongoing downtimes ending now
+func downtimeBounds(d Downtime) (from, to time.Time, ok bool) {
+	from, err := time.Parse(time.RFC3339, d.StartedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if d.EndedAt == "" {
+		return from, time.Now(), true
+	}
+	to, err = time.Parse(time.RFC3339, d.EndedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
 }
 
 func max(a, b int) int {
@@ -0,0 +1,91 @@
+package updown
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DryRunHeader flags the responses synthesized in dry-run mode
+const DryRunHeader = "X-Updown-Dry-Run"
+
+// DryRunRequest is a request which was not sent in dry-run mode
+type DryRunRequest struct {
+	Method string
+	// Path of the request, relative to the base URL of the client
+	Path string
+	// JSON body of the request, if any
+	Body []byte
+}
+
+// DryRunRecorder records the requests which were not sent in dry-run mode, see WithDryRun
+type DryRunRecorder struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// Requests gives the recorded requests, in the order they were made
+func (r *DryRunRecorder) Requests() []DryRunRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunRequest(nil), r.requests...)
+}
+
+func (r *DryRunRecorder) record(req DryRunRequest) {
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	r.mu.Unlock()
+}
+
+// WithDryRun records the requests adding, updating or removing resources instead of sending them,
+// e.g. to test automation scripts against a production account. Read requests are still sent.
+// Mutating calls return the data they were given, without token, and removals report a deletion.
+// Responses are flagged with the DryRunHeader header. Requests are logged as well with WithLogger
+func WithDryRun(recorder *DryRunRecorder) Option {
+	return func(c *Client) error {
+		if recorder == nil {
+			recorder = &DryRunRecorder{}
+		}
+		c.dryRun = recorder
+		return nil
+	}
+}
+
+// dryRunResponse records a mutating request and synthesizes its response
+func (c *Client) dryRunResponse(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, c.BaseURL.Path)
+	c.dryRun.record(DryRunRequest{Method: req.Method, Path: path, Body: body})
+	if c.logger != nil {
+		c.logger.InfoContext(req.Context(), "updown dry run", "method", req.Method, "path", path)
+	}
+
+	res := body
+	if req.Method == "DELETE" || len(bytes.TrimSpace(res)) == 0 {
+		res = []byte(`{"deleted":true}`)
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", mediaType)
+	header.Set(DryRunHeader, "true")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(res)),
+		ContentLength: int64(len(res)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDryRun(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`[{"token":"abcd"}]`))
+	}))
+	defer server.Close()
+
+	recorder := &DryRunRecorder{}
+	client := newTestClient(t, server.URL, WithDryRun(recorder))
+
+	checks, _, err := client.Check.List()
+	require.NoError(t, err)
+	assert.Len(t, checks, 1)
+
+	check, resp, err := client.Check.Add(CheckItem{URL: "https://example.com", Alias: "Example"})
+	require.NoError(t, err)
+	assert.Equal(t, "true", resp.Header.Get(DryRunHeader))
+	assert.Equal(t, Check{URL: "https://example.com", Alias: "Example"}, check)
+
+	deleted, _, err := client.Check.Remove("abcd")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	_, _, err = client.Recipient.Add(RecipientItem{Type: RecipientTypeEmail, Value: "ops@example.com"})
+	require.NoError(t, err)
+
+	// Only reads reached the API
+	assert.Equal(t, []string{"GET"}, methods)
+
+	requests := recorder.Requests()
+	require.Len(t, requests, 3)
+	assert.Equal(t, "POST", requests[0].Method)
+	assert.Equal(t, "checks", requests[0].Path)
+	assert.JSONEq(t, `{"url":"https://example.com","alias":"Example","enabled":false,"published":false}`, string(requests[0].Body))
+	assert.Equal(t, DryRunRequest{Method: "DELETE", Path: "checks/abcd", Body: []byte{}}, requests[1])
+	assert.Equal(t, "recipients", requests[2].Path)
+}
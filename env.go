@@ -0,0 +1,59 @@
+package updown
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv
+const (
+	EnvAPIKey  = "UPDOWN_API_KEY"
+	EnvBaseURL = "UPDOWN_BASE_URL"
+	EnvTimeout = "UPDOWN_TIMEOUT"
+)
+
+// ErrMissingAPIKey indicates that the UPDOWN_API_KEY environment variable is not set
+var ErrMissingAPIKey = errors.New("API key is not set, set the UPDOWN_API_KEY environment variable")
+
+// NewClientFromEnv returns a new API client using the API key set by UPDOWN_API_KEY. The optional
+// UPDOWN_BASE_URL and UPDOWN_TIMEOUT variables set the base URL and the timeout of requests, as
+// a duration such as "30s" or a number of seconds. The given options take precedence
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	apiKey := os.Getenv(EnvAPIKey)
+	if apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	var envOpts []Option
+	if baseURL := os.Getenv(EnvBaseURL); baseURL != "" {
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := parseEnvTimeout(value)
+		if err != nil {
+			return nil, err
+		}
+		envOpts = append(envOpts, WithTimeout(timeout))
+	}
+
+	client, err := NewClient(apiKey, append(envOpts, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("updown: configuring client from environment: %w", err)
+	}
+	return client, nil
+}
+
+// parseEnvTimeout reads a timeout given as a duration or a number of seconds
+func parseEnvTimeout(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("updown: invalid %s %q, expecting a duration such as 30s", EnvTimeout, value)
+	}
+	return timeout, nil
+}
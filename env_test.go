@@ -0,0 +1,44 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	_, err := NewClientFromEnv()
+	assert.Equal(t, ErrMissingAPIKey, err)
+
+	t.Setenv(EnvAPIKey, "key")
+	client, err := NewClientFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "key", client.APIKey)
+	assert.Equal(t, defaultBaseURL, client.BaseURL.String())
+	assert.Equal(t, DefaultTimeout, client.timeout)
+
+	t.Setenv(EnvBaseURL, "https://gateway.internal/updown/api")
+	t.Setenv(EnvTimeout, "15")
+	client, err = NewClientFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "https://gateway.internal/updown/api/", client.BaseURL.String())
+	assert.Equal(t, 15*time.Second, client.timeout)
+
+	// Options take precedence
+	t.Setenv(EnvTimeout, "2m")
+	client, err = NewClientFromEnv(WithTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.timeout)
+
+	t.Setenv(EnvTimeout, "soon")
+	_, err = NewClientFromEnv()
+	assert.ErrorContains(t, err, EnvTimeout)
+
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvBaseURL, "ftp://updown.io")
+	_, err = NewClientFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package updown
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNotFound indicates that the requested resource does not exist, e.g. a deleted check
+	ErrNotFound = errors.New("Resource not found")
+	// ErrUnauthorized indicates that the API key is missing, invalid or lacks permissions
+	ErrUnauthorized = errors.New("Unauthorized request")
+	// ErrRateLimited indicates that the API rejected the request because of its rate limit
+	ErrRateLimited = errors.New("Rate limit exceeded")
+	// ErrInvalidRequest indicates that the API rejected the parameters of the request
+	ErrInvalidRequest = errors.New("Invalid request")
+)
+
+// Is makes API errors match the sentinel error of their status code with errors.Is
+func (r *ErrorResponse) Is(target error) bool {
+	switch r.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return target == ErrInvalidRequest
+	}
+	return false
+}
+
+// NetworkError reports a failure to reach the API, such as a DNS, connection or timeout error.
+// The underlying error, usually a *url.Error, can be inspected with errors.As
+type NetworkError struct {
+	Err error
+
+	// ID of the request, see RequestIDHeader
+	RequestID string
+
+	// Attempts made before giving up
+	Attempts RetryInfo
+}
+
+func (e *NetworkError) Error() string {
+	msg := e.Err.Error()
+	if e.Attempts.Attempts > 1 {
+		msg += ", after " + e.Attempts.String()
+	}
+	if e.RequestID != "" {
+		msg += " (request " + e.RequestID + ")"
+	}
+	return msg
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary tells if the request may succeed when sent again: after timeouts and connection
+// errors, but not after certificate verification errors or invalid URLs
+func (e *NetworkError) Temporary() bool {
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	switch {
+	case errors.As(e.Err, &certErr), errors.As(e.Err, &unknownAuthority),
+		errors.As(e.Err, &hostnameErr), errors.As(e.Err, &invalidCert):
+		return false
+	}
+
+	var urlErr *url.Error
+	if !errors.As(e.Err, &urlErr) {
+		return true
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return urlErr.Timeout() || errors.As(urlErr, &opErr) || errors.As(urlErr, &dnsErr) ||
+		errors.Is(urlErr, io.EOF) || errors.Is(urlErr, io.ErrUnexpectedEOF)
+}
+
+// Temporary tells if the request may succeed when sent again later, for rate limits and server errors
+func (r *ErrorResponse) Temporary() bool {
+	return r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= 500
+}
+
+// IsTemporary tells if an error returned by the client may go away when sending the request again
+// later, e.g. to write retry logic. Errors caused by the cancellation or the deadline of the context
+// of the caller are not temporary
+func IsTemporary(err error) bool {
+	// Timeouts of the client itself are reported as network errors, wrapping the deadline
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		return netErr.Temporary()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	var t interface{ Temporary() bool }
+	return errors.As(err, &t) && t.Temporary()
+}
+
+// ValidationError is a message of the API about an invalid parameter, e.g. the URL of a check
+type ValidationError struct {
+	// Name of the parameter, empty when the message is about the whole request
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + " " + e.Message
+}
+
+// parseValidationErrors reads the validation errors of a 422 response body, given either per
+// field as {"errors": {"url": ["is invalid"]}}, or as a list of messages. The "error" key is
+// accepted as well
+func parseValidationErrors(data []byte) []ValidationError {
+	var body struct {
+		Errors json.RawMessage `json:"errors"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return nil
+	}
+
+	var res []ValidationError
+	for _, raw := range []json.RawMessage{body.Errors, body.Error} {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) == nil {
+			for field, messages := range fields {
+				for _, message := range validationMessages(messages) {
+					res = append(res, ValidationError{Field: field, Message: message})
+				}
+			}
+			continue
+		}
+		var list []string
+		if json.Unmarshal(raw, &list) == nil {
+			for _, message := range list {
+				res = append(res, ValidationError{Message: message})
+			}
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Field < res[j].Field })
+	return res
+}
+
+// validationMessages reads the messages of a field, given as a string or a list of strings
+func validationMessages(raw json.RawMessage) []string {
+	var message string
+	if json.Unmarshal(raw, &message) == nil {
+		return []string{message}
+	}
+	var messages []string
+	json.Unmarshal(raw, &messages)
+	return messages
+}
+
+// joinValidationErrors summarizes validation errors as a message
+func joinValidationErrors(errs []ValidationError) string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, ", ")
+}
@@ -0,0 +1,174 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorResponse(t *testing.T) {
+	body := `{"error":"URL is not a valid URL"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	_, _, err := client.Check.Add(CheckItem{URL: "nope"})
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusBadRequest, errResp.StatusCode)
+	assert.Equal(t, "URL is not a valid URL", errResp.Message)
+	assert.Equal(t, body, string(errResp.Body))
+	assert.Contains(t, err.Error(), "POST")
+
+	// Bodies which are not JSON are used as the message
+	_, _, err = client.Check.Remove("abcd")
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusServiceUnavailable, errResp.StatusCode)
+	assert.Equal(t, "Service Unavailable\n", errResp.Message)
+}
+
+func TestErrorResponseIs(t *testing.T) {
+	for status, sentinel := range map[int]error{
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrUnauthorized,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusBadRequest:          ErrInvalidRequest,
+		http.StatusUnprocessableEntity: ErrInvalidRequest,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		client := newTestClient(t, server.URL)
+
+		_, _, err := client.Check.Get("abcd")
+		assert.ErrorIs(t, err, sentinel, status)
+		for _, other := range []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrInvalidRequest} {
+			if other != sentinel {
+				assert.NotErrorIs(t, err, other, status)
+			}
+		}
+		server.Close()
+	}
+
+	assert.NotErrorIs(t, &ErrorResponse{StatusCode: http.StatusInternalServerError}, ErrNotFound)
+}
+
+func TestValidationErrors(t *testing.T) {
+	for _, tc := range []struct {
+		body     string
+		expected []ValidationError
+		message  string
+	}{
+		{
+			body: `{"errors":{"url":["is invalid","is too long"],"period":"is not included in the list"}}`,
+			expected: []ValidationError{
+				{Field: "period", Message: "is not included in the list"},
+				{Field: "url", Message: "is invalid"},
+				{Field: "url", Message: "is too long"},
+			},
+			message: "period is not included in the list, url is invalid, url is too long",
+		},
+		{
+			body:     `{"errors":["Name can't be blank"]}`,
+			expected: []ValidationError{{Message: "Name can't be blank"}},
+			message:  "Name can't be blank",
+		},
+		{
+			body:     `{"error":"URL is invalid"}`,
+			expected: nil,
+			message:  "URL is invalid",
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(tc.body))
+		}))
+		client := newTestClient(t, server.URL)
+
+		_, _, err := client.StatusPage.Add(StatusPageItem{})
+		var errResp *ErrorResponse
+		require.True(t, errors.As(err, &errResp))
+		assert.Equal(t, tc.expected, errResp.ValidationErrors, tc.body)
+		assert.Equal(t, tc.message, errResp.Message, tc.body)
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		server.Close()
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/checks/down":
+			w.WriteHeader(http.StatusBadGateway)
+		case "/checks/gone":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`{"token":`))
+		}
+	}))
+	client := newTestClient(t, server.URL)
+
+	_, _, err := client.Check.Get("down")
+	assert.True(t, IsTemporary(err))
+
+	_, _, err = client.Check.Get("gone")
+	assert.False(t, IsTemporary(err))
+
+	// Decode errors are wrapped
+	_, _, err = client.Check.Get("truncated")
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.False(t, IsTemporary(err))
+
+	// Network errors keep the underlying error
+	server.Close()
+	_, _, err = client.Check.Get("abcd")
+	var netErr *NetworkError
+	require.True(t, errors.As(err, &netErr))
+	var urlErr *url.Error
+	assert.True(t, errors.As(err, &urlErr))
+	assert.True(t, IsTemporary(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = client.Check.GetCtx(ctx, "abcd")
+	assert.False(t, IsTemporary(err))
+	assert.False(t, IsTemporary(fmt.Errorf("listing checks: %w", context.Canceled)))
+	assert.True(t, IsTemporary(ErrCircuitOpen))
+}
+
+func TestNetworkErrorTemporary(t *testing.T) {
+	// Certificates which cannot be verified will not be on the next attempt
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	_, _, err := client.Check.Get("abcd")
+	var netErr *NetworkError
+	require.ErrorAs(t, err, &netErr)
+	assert.False(t, IsTemporary(err))
+
+	// Nor invalid requests
+	_, err = http.Get("unsupported://example.com")
+	assert.False(t, IsTemporary(&NetworkError{Err: err}))
+
+	// While timeouts and connection errors may go away
+	timeout := &url.Error{Op: "Get", URL: "https://updown.io", Err: context.DeadlineExceeded}
+	assert.True(t, IsTemporary(&NetworkError{Err: timeout}))
+	reset := &url.Error{Op: "Get", URL: "https://updown.io", Err: io.EOF}
+	assert.True(t, IsTemporary(&NetworkError{Err: reset}))
+}
@@ -0,0 +1,100 @@
+package updown
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultMaxETagEntries is how many responses are kept by the ETag cache
+const DefaultMaxETagEntries = 256
+
+// WithETagCache keeps the responses of GET requests having an ETag in memory, and sends their
+// ETag with the next identical requests. When the API answers 304 Not Modified, the cached
+// response is returned, cutting bandwidth and latency when polling data which rarely changes.
+// Up to DefaultMaxETagEntries responses are kept, the least recently used ones being dropped first
+func WithETagCache() Option {
+	return func(c *Client) error {
+		c.etags = &etagCache{limit: DefaultMaxETagEntries}
+		return nil
+	}
+}
+
+type etagEntry struct {
+	etag   string
+	body   []byte
+	header http.Header
+}
+
+// etagCache keeps the last response having an ETag of the most recently used GET requests
+type etagCache struct {
+	mu      sync.Mutex
+	entries lru[etagEntry]
+	limit   int
+}
+
+// prepare makes a GET request conditional when a response to it is cached
+func (c *etagCache) prepare(req *http.Request) {
+	if req.Method != "GET" {
+		return
+	}
+	c.mu.Lock()
+	entry, has := c.entries.get(staleKey(req))
+	c.mu.Unlock()
+	if has {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// store records the body of a successful response having an ETag, which is replaced by an in-memory copy
+func (c *etagCache) store(resp *http.Response) error {
+	etag := resp.Header.Get("ETag")
+	if etag == "" || resp.Request.Method != "GET" {
+		return nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	c.mu.Lock()
+	c.entries.put(staleKey(resp.Request), etagEntry{etag: etag, body: data, header: resp.Header.Clone()}, c.limit)
+	c.mu.Unlock()
+	return nil
+}
+
+// notModified replaces a 304 response by the cached one, if any
+func (c *etagCache) notModified(resp *http.Response) (*http.Response, bool) {
+	if resp.StatusCode != http.StatusNotModified {
+		return resp, false
+	}
+	c.mu.Lock()
+	entry, has := c.entries.get(staleKey(resp.Request))
+	c.mu.Unlock()
+	if !has {
+		return resp, false
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	header := entry.header.Clone()
+	for key, values := range resp.Header {
+		// Fresh rate limit and caching headers
+		header[key] = values
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      resp.Proto,
+		ProtoMajor: resp.ProtoMajor,
+		ProtoMinor: resp.ProtoMinor,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(entry.body)),
+		Request:    resp.Request,
+	}, true
+}
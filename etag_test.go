@@ -0,0 +1,63 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithETagCache(t *testing.T) {
+	var conditional []string
+	etag := `"v1"`
+	body := `[{"token":"abcd"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL, WithETagCache())
+
+	for i := 0; i < 2; i++ {
+		checks, resp, err := client.Check.List()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []Check{{Token: "abcd"}}, checks)
+	}
+
+	etag, body = `"v2"`, `[{"token":"efgh"}]`
+	checks, _, err := client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Check{{Token: "efgh"}}, checks)
+	assert.Equal(t, []string{"", `"v1"`, `"v1"`}, conditional)
+
+	// Requests are only conditional when a response is cached
+	_, _, err = client.Webhook.List()
+	require.NoError(t, err)
+	assert.Equal(t, "", conditional[3])
+}
+
+func TestETagCacheLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL, WithETagCache())
+	client.etags.limit = 2
+
+	for _, token := range []string{"a", "b", "c"} {
+		_, _, err := client.Check.Get(token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, client.etags.entries.order.Len())
+	_, has := client.etags.entries.get("http://" + server.Listener.Addr().String() + "/checks/a")
+	assert.False(t, has)
+}
@@ -0,0 +1,51 @@
+package updown
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// EventType is the type of an event sent by Updown to webhooks
+type EventType string
+
+const (
+	EventCheckDown            EventType = "check.down"
+	EventCheckUp              EventType = "check.up"
+	EventCheckSSLInvalid      EventType = "check.ssl_invalid"
+	EventCheckSSLValid        EventType = "check.ssl_valid"
+	EventCheckSSLExpiration   EventType = "check.ssl_expiration"
+	EventCheckSSLRenewed      EventType = "check.ssl_renewed"
+	EventCheckPerformanceDrop EventType = "check.performance_drop"
+)
+
+func (e EventType) String() string {
+	return string(e)
+}
+
+// Known tells if the event type is one of the types defined by this package
+func (e EventType) Known() bool {
+	switch e {
+	case EventCheckDown, EventCheckUp, EventCheckSSLInvalid, EventCheckSSLValid,
+		EventCheckSSLExpiration, EventCheckSSLRenewed, EventCheckPerformanceDrop:
+		return true
+	}
+	return false
+}
+
+// WebhookEvent is an event sent by Updown to webhooks
+type WebhookEvent struct {
+	Event       EventType `json:"event"`
+	Time        string    `json:"time,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Check       Check     `json:"check"`
+	Downtime    Downtime  `json:"downtime,omitempty"`
+}
+
+// ParseWebhookEvents decodes the body of a webhook call, which holds a list of events
+func ParseWebhookEvents(r io.Reader) ([]WebhookEvent, error) {
+	var events []WebhookEvent
+	if err := json.NewDecoder(r).Decode(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,32 @@
+package updown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookEvents(t *testing.T) {
+	body := `[{
+		"event": "check.down",
+		"time": "2024-01-01T10:00:00Z",
+		"description": "DOWN since 10:00",
+		"check": {"token": "abcd", "url": "https://example.com", "down": true},
+		"downtime": {"error": "500 Internal Server Error", "started_at": "2024-01-01T10:00:00Z"}
+	}, {"event": "check.unknown", "check": {"token": "efgh"}}]`
+
+	events, err := ParseWebhookEvents(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, EventCheckDown, events[0].Event)
+	assert.Equal(t, "check.down", events[0].Event.String())
+	assert.True(t, events[0].Event.Known())
+	assert.Equal(t, "abcd", events[0].Check.Token)
+	assert.True(t, events[0].Check.Down)
+	assert.Equal(t, "500 Internal Server Error", events[0].Downtime.Error)
+
+	assert.False(t, events[1].Event.Known())
+}
@@ -0,0 +1,212 @@
+package updown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrFanOutQueueFull indicates that a webhook was dropped because the queue of a destination was full
+var ErrFanOutQueueFull = errors.New("Webhook dropped, the destination queue is full")
+
+// DefaultFanOutTimeout is the time given to a destination to answer a delivery by default
+const DefaultFanOutTimeout = 10 * time.Second
+
+// DefaultFanOutMaxBodySize is the size of the largest webhook relayed by default
+const DefaultFanOutMaxBodySize = 1 << 20
+
+// FanOutConfig describes a WebhookFanOut
+type FanOutConfig struct {
+	// URLs the webhooks are relayed to, once each even when listed several times
+	Destinations []string
+	// Number of webhooks waiting for delivery per destination, 100 by default
+	QueueSize int
+	// HTTP client used to deliver webhooks, http.DefaultClient by default
+	HTTPClient *http.Client
+	// Size in bytes of the largest webhook accepted, DefaultFanOutMaxBodySize by default.
+	// Larger ones are refused with a 413 status
+	MaxBodySize int64
+	// Maximum time of a delivery attempt, DefaultFanOutTimeout by default, so that a
+	// destination which never answers does not block its queue
+	Timeout time.Duration
+	// Retry policy of deliveries. Webhooks are relayed as POST requests, retried whatever
+	// RetryNonIdempotent. Deliveries are attempted once when MaxAttempts is below 2
+	Retry RetryPolicy
+	// OnError, when set, is called when a webhook is given up for a destination. It is called
+	// from ServeHTTP, or from the goroutine delivering to the destination, where calling Close
+	// would wait for this very goroutine
+	OnError func(destination string, err error)
+}
+
+// WebhookFanOut is an http.Handler receiving updown webhooks once and relaying them to
+// several downstream URLs, each one having its own retry queue
+type WebhookFanOut struct {
+	cfg    FanOutConfig
+	queues map[string]chan fanOutWebhook
+	wg     sync.WaitGroup
+	// Closed by Close, to stop waiting before retries
+	stop chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+type fanOutWebhook struct {
+	body        []byte
+	contentType string
+}
+
+// NewWebhookFanOut creates a fan-out and starts delivering to its destinations
+func NewWebhookFanOut(cfg FanOutConfig) *WebhookFanOut {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultFanOutTimeout
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = DefaultFanOutMaxBodySize
+	}
+
+	f := &WebhookFanOut{cfg: cfg, queues: make(map[string]chan fanOutWebhook), stop: make(chan struct{})}
+	for _, dest := range cfg.Destinations {
+		if _, has := f.queues[dest]; has {
+			continue
+		}
+		queue := make(chan fanOutWebhook, cfg.QueueSize)
+		f.queues[dest] = queue
+		f.wg.Add(1)
+		go f.deliver(dest, queue)
+	}
+	return f
+}
+
+// ServeHTTP queues the received webhook for every destination, and acknowledges it right away
+func (f *WebhookFanOut) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, f.cfg.MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		return
+	}
+
+	webhook := fanOutWebhook{body: body, contentType: r.Header.Get("Content-Type")}
+	var dropped []string
+	f.mu.RLock()
+	closed := f.closed
+	if !closed {
+		for dest, queue := range f.queues {
+			select {
+			case queue <- webhook:
+			default:
+				dropped = append(dropped, dest)
+			}
+		}
+	}
+	f.mu.RUnlock()
+
+	if closed {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	// Reported once the lock is released, for OnError to be able to call Close
+	for _, dest := range dropped {
+		f.fail(dest, ErrFanOutQueueFull)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Close stops accepting webhooks and waits for the queued ones to be delivered or given up.
+// Retries do not wait for their backoff anymore once closing: failed webhooks get a last attempt
+// right away
+func (f *WebhookFanOut) Close() {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		close(f.stop)
+		for _, queue := range f.queues {
+			close(queue)
+		}
+	}
+	f.mu.Unlock()
+	f.wg.Wait()
+}
+
+func (f *WebhookFanOut) deliver(dest string, queue chan fanOutWebhook) {
+	defer f.wg.Done()
+
+	attempts := max(1, f.cfg.Retry.MaxAttempts)
+	for webhook := range queue {
+		var err error
+		for attempt := 1; attempt <= attempts; attempt++ {
+			if attempt > 1 && !f.wait(f.cfg.Retry.backoff(attempt-1)) {
+				// Closing: make the last attempt right away
+				attempt = attempts
+			}
+			if err = f.post(dest, webhook); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			f.fail(dest, err)
+		}
+	}
+}
+
+// wait waits before a retry, and tells false when interrupted by Close
+func (f *WebhookFanOut) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-f.stop:
+		return false
+	}
+}
+
+func (f *WebhookFanOut) post(dest string, webhook fanOutWebhook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), f.cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", dest, bytes.NewReader(webhook.body))
+	if err != nil {
+		return err
+	}
+	if webhook.contentType != "" {
+		req.Header.Set("Content-Type", webhook.contentType)
+	}
+
+	resp, err := f.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook delivery to %s: %s", dest, resp.Status)
+	}
+	return nil
+}
+
+func (f *WebhookFanOut) fail(dest string, err error) {
+	if f.cfg.OnError != nil {
+		f.cfg.OnError(dest, err)
+	}
+}
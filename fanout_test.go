@@ -0,0 +1,159 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookFanOut(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]string{}
+	downstream := func(name string, failures int) *httptest.Server {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if calls <= failures {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			received[name] = append(received[name], r.Header.Get("Content-Type")+" "+string(body))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	flaky, healthy, broken := downstream("flaky", 1), downstream("healthy", 0), downstream("broken", 10)
+
+	var failed []string
+	fanOut := NewWebhookFanOut(FanOutConfig{
+		Destinations: []string{flaky.URL, healthy.URL, broken.URL},
+		Retry:        RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+		OnError: func(dest string, err error) {
+			mu.Lock()
+			failed = append(failed, dest)
+			mu.Unlock()
+		},
+	})
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`[{"event":"check.down"}]`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	fanOut.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	fanOut.Close()
+	expected := []string{`application/json [{"event":"check.down"}]`}
+	assert.Equal(t, expected, received["flaky"])
+	assert.Equal(t, expected, received["healthy"])
+	assert.Empty(t, received["broken"])
+	assert.Equal(t, []string{broken.URL}, failed)
+
+	// Closed fan-outs refuse webhooks
+	rec = httptest.NewRecorder()
+	fanOut.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`[]`)))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestWebhookFanOutTimeout(t *testing.T) {
+	// A destination which never answers
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	defer server.Close()
+	defer close(hang)
+
+	var mu sync.Mutex
+	var errs []error
+	fanOut := NewWebhookFanOut(FanOutConfig{
+		Destinations: []string{server.URL},
+		Timeout:      50 * time.Millisecond,
+		Retry:        RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour},
+		OnError: func(dest string, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	})
+	fanOut.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(`[]`)))
+	time.Sleep(100 * time.Millisecond)
+
+	// Close interrupts the backoff, and the last attempt times out too
+	start := time.Now()
+	fanOut.Close()
+	assert.Less(t, time.Since(start), time.Second)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.DeadlineExceeded)
+}
+
+func TestWebhookFanOutDuplicateDestinations(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	fanOut := NewWebhookFanOut(FanOutConfig{Destinations: []string{server.URL, server.URL}})
+	fanOut.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(`[]`)))
+
+	// Close returns once the single delivery is done
+	fanOut.Close()
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookFanOutLimits(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer server.Close()
+
+	var fanOut *WebhookFanOut
+	closed := make(chan struct{})
+	fanOut = NewWebhookFanOut(FanOutConfig{
+		Destinations: []string{server.URL},
+		QueueSize:    1,
+		MaxBodySize:  16,
+		OnError: func(dest string, err error) {
+			if errors.Is(err, ErrFanOutQueueFull) {
+				// Closing from the callback does not deadlock
+				close(block)
+				fanOut.Close()
+				close(closed)
+			}
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	fanOut.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`[{"event":"check.down"}]`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	// The first webhook is being delivered, the second one queued, the third one dropped
+	go func() {
+		for range 3 {
+			fanOut.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader(`[]`)))
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return")
+	}
+}
@@ -0,0 +1,46 @@
+package updown
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response is an API response, along with the metadata read from its headers
+type Response struct {
+	*http.Response
+
+	// Rate limit reported by the response, see HasRateLimit
+	RateLimit    RateLimit
+	HasRateLimit bool
+
+	// Time of the data of a stale response, zero when fresh. See Client.ServeStale
+	StaleSince time.Time
+}
+
+// Do sends an API request and decodes its JSON response into a T, e.g. to call endpoints
+// not modeled by this package yet. The request is built with NewRequest or NewRequestCtx
+func Do[T any](c *Client, req *http.Request) (T, *Response, error) {
+	res, resp, err := do[T](c, req)
+	return res, newResponse(resp), err
+}
+
+// do is like Do, returning the HTTP response as services do
+func do[T any](c *Client, req *http.Request) (T, *http.Response, error) {
+	var res T
+	resp, err := c.Do(req, &res)
+	if err != nil {
+		var zero T
+		return zero, resp, err
+	}
+	return res, resp, nil
+}
+
+func newResponse(resp *http.Response) *Response {
+	if resp == nil {
+		return nil
+	}
+	res := &Response{Response: resp}
+	res.RateLimit, res.HasRateLimit = ParseRateLimit(resp)
+	res.StaleSince, _ = StaleSince(resp)
+	return res
+}
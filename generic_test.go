@@ -0,0 +1,46 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		w.Write([]byte(`{"beta":true,"count":3}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	type feature struct {
+		Beta  bool `json:"beta"`
+		Count int  `json:"count"`
+	}
+	req, err := client.NewRequest("GET", "features", nil)
+	require.NoError(t, err)
+	res, resp, err := Do[feature](client, req)
+	require.NoError(t, err)
+	assert.Equal(t, feature{Beta: true, Count: 3}, res)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, resp.HasRateLimit)
+	assert.Equal(t, 99, resp.RateLimit.Remaining)
+	assert.True(t, resp.StaleSince.IsZero())
+
+	req, err = client.NewRequest("GET", "missing", nil)
+	require.NoError(t, err)
+	res, resp, err = Do[feature](client, req)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Zero(t, res)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
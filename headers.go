@@ -0,0 +1,99 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithUserAgentSuffix appends a suffix to the User-Agent header sent with every request,
+// e.g. "my-app/1.0" to be sent as "Go Updown v0.3 my-app/1.0"
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Client) error {
+		c.userAgentSuffix = joinUserAgent(c.userAgentSuffix, suffix)
+		return nil
+	}
+}
+
+// WithAppInfo identifies the application using the client in the User-Agent header, as
+// "name/version (+contact)", so updown support can attribute its traffic. The version and
+// contact, e.g. a URL or an email address, are optional
+func WithAppInfo(name, version, contact string) Option {
+	info := name
+	if version != "" {
+		info += "/" + version
+	}
+	if contact != "" {
+		info += " (+" + contact + ")"
+	}
+	return WithUserAgentSuffix(info)
+}
+
+// WithHeader adds a header sent with every request, e.g. an internal correlation header.
+// It replaces the headers set by the client, such as Accept
+func WithHeader(key, value string) Option {
+	return func(c *Client) error {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+		return nil
+	}
+}
+
+type headersKey struct{}
+
+type userAgentSuffixKey struct{}
+
+// ContextWithHeaders adds headers to the requests made with the returned context, on top of the
+// default headers of the client, which they replace
+func ContextWithHeaders(ctx context.Context, header http.Header) context.Context {
+	merged := make(http.Header)
+	if parent, ok := ctx.Value(headersKey{}).(http.Header); ok {
+		for key, values := range parent {
+			merged[key] = values
+		}
+	}
+	for key, values := range header {
+		merged[http.CanonicalHeaderKey(key)] = values
+	}
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// ContextWithUserAgentSuffix appends a suffix to the User-Agent header of the requests made with
+// the returned context
+func ContextWithUserAgentSuffix(ctx context.Context, suffix string) context.Context {
+	if parent, ok := ctx.Value(userAgentSuffixKey{}).(string); ok {
+		suffix = joinUserAgent(parent, suffix)
+	}
+	return context.WithValue(ctx, userAgentSuffixKey{}, suffix)
+}
+
+// setHeaders sets the default headers of the client and the ones of the request context
+func (c *Client) setHeaders(req *http.Request) {
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if header, ok := req.Context().Value(headersKey{}).(http.Header); ok {
+		for key, values := range header {
+			req.Header[key] = append([]string(nil), values...)
+		}
+	}
+	suffix := c.userAgentSuffix
+	if ctxSuffix, ok := req.Context().Value(userAgentSuffixKey{}).(string); ok {
+		suffix = joinUserAgent(suffix, ctxSuffix)
+	}
+	if suffix != "" {
+		req.Header.Set("User-Agent", joinUserAgent(req.Header.Get("User-Agent"), suffix))
+	}
+}
+
+func joinUserAgent(ua, suffix string) string {
+	switch {
+	case suffix == "":
+		return ua
+	case ua == "":
+		return suffix
+	default:
+		return ua + " " + suffix
+	}
+}
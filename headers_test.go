@@ -0,0 +1,56 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaders(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL,
+		WithUserAgentSuffix("my-app/1.0"),
+		WithUserAgent("custom"),
+		WithHeader("X-Correlation-Source", "provisioning"),
+		WithHeader("X-Team", "ops"),
+	)
+
+	_, _, err := client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "custom my-app/1.0", header.Get("User-Agent"))
+	assert.Equal(t, "provisioning", header.Get("X-Correlation-Source"))
+	assert.Equal(t, "ops", header.Get("X-Team"))
+	assert.Equal(t, "key", header.Get("X-Api-Key"))
+
+	ctx := ContextWithHeaders(context.Background(), http.Header{"x-team": {"payments"}})
+	ctx = ContextWithHeaders(ctx, http.Header{"X-Correlation-ID": {"1234"}})
+	ctx = ContextWithUserAgentSuffix(ctx, "job/42")
+	_, _, err = client.Check.ListCtx(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "custom my-app/1.0 job/42", header.Get("User-Agent"))
+	assert.Equal(t, "provisioning", header.Get("X-Correlation-Source"))
+	assert.Equal(t, []string{"payments"}, header.Values("X-Team"))
+	assert.Equal(t, "1234", header.Get("X-Correlation-ID"))
+}
+
+func TestWithAppInfo(t *testing.T) {
+	client := newTestClient(t, "http://updown.invalid", WithAppInfo("myapp", "1.2", "https://myapp.example.com"))
+	req, err := client.NewRequest("GET", "checks", nil)
+	require.NoError(t, err)
+	assert.Equal(t, userAgent+" myapp/1.2 (+https://myapp.example.com)", req.Header.Get("User-Agent"))
+
+	client = newTestClient(t, "http://updown.invalid", WithAppInfo("myapp", "", ""))
+	req, err = client.NewRequest("GET", "checks", nil)
+	require.NoError(t, err)
+	assert.Equal(t, userAgent+" myapp", req.Header.Get("User-Agent"))
+}
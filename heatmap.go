@@ -0,0 +1,90 @@
+package updown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Heatmap holds response times bucketed by day of week and hour of day
+type Heatmap struct {
+	// Average total response time in milliseconds, by weekday then hour. Zero without samples
+	Values [7][24]float64
+	// Number of samples, by weekday then hour
+	Samples [7][24]int
+}
+
+// NewHeatmap buckets metrics grouped by time into a heatmap, in the given location
+// (UTC when nil). Averages are weighted by the number of samples of each metric
+func NewHeatmap(metrics Metrics, loc *time.Location) Heatmap {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var h Heatmap
+	var totals [7][24]float64
+	for key, m := range metrics {
+		t, err := time.Parse(time.RFC3339, key)
+		if err != nil {
+			continue
+		}
+		t = t.In(loc)
+		weight := max(1, m.Requests.Samples)
+		totals[t.Weekday()][t.Hour()] += float64(m.Timings.Total * weight)
+		h.Samples[t.Weekday()][t.Hour()] += weight
+	}
+
+	for day := range h.Values {
+		for hour := range h.Values[day] {
+			if n := h.Samples[day][hour]; n > 0 {
+				h.Values[day][hour] = totals[day][hour] / float64(n)
+			}
+		}
+	}
+	return h
+}
+
+// Max gives the highest average response time of the heatmap
+func (h Heatmap) Max() float64 {
+	res := 0.0
+	for _, row := range h.Values {
+		for _, v := range row {
+			if v > res {
+				res = v
+			}
+		}
+	}
+	return res
+}
+
+// SVG renders the heatmap as an SVG image, days as rows starting on Monday and hours as
+// columns, from green for the fastest to red for the slowest response times
+func (h Heatmap) SVG(w io.Writer) error {
+	const cell, left, top = 20, 40, 20
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="10">`+"\n",
+		left+24*cell, top+7*cell)
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(bw, `<text x="%d" y="%d">%02d</text>`+"\n", left+hour*cell+4, top-6, hour)
+	}
+
+	highest := h.Max()
+	for row := 0; row < 7; row++ {
+		day := time.Weekday((row + 1) % 7)
+		fmt.Fprintf(bw, `<text x="0" y="%d">%s</text>`+"\n", top+row*cell+14, day.String()[:3])
+		for hour := 0; hour < 24; hour++ {
+			color := "#eeeeee"
+			if h.Samples[day][hour] > 0 && highest > 0 {
+				// From green (120°) for fast to red (0°) for slow
+				color = fmt.Sprintf("hsl(%.0f,70%%,50%%)", 120*(1-h.Values[day][hour]/highest))
+			}
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s %02d:00 %.0fms</title></rect>`+"\n",
+				left+hour*cell, top+row*cell, cell-1, cell-1, color, day, hour, h.Values[day][hour])
+		}
+	}
+	fmt.Fprintln(bw, `</svg>`)
+
+	return bw.Flush()
+}
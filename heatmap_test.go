@@ -0,0 +1,45 @@
+package updown
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	metrics := Metrics{
+		// Mondays at 10:00 UTC
+		"2024-04-01T10:00:00Z": {Timings: Timings{Total: 100}, Requests: Requests{Samples: 3}},
+		"2024-04-08T10:00:00Z": {Timings: Timings{Total: 500}, Requests: Requests{Samples: 1}},
+		// Sunday at 23:00 UTC, Monday at 01:00 in Paris
+		"2024-04-07T23:00:00Z": {Timings: Timings{Total: 300}, Requests: Requests{Samples: 2}},
+		"invalid":              {Timings: Timings{Total: 1000}},
+	}
+
+	h := NewHeatmap(metrics, nil)
+	assert.Equal(t, 200.0, h.Values[time.Monday][10])
+	assert.Equal(t, 4, h.Samples[time.Monday][10])
+	assert.Equal(t, 300.0, h.Values[time.Sunday][23])
+	assert.Equal(t, 300.0, h.Max())
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	h = NewHeatmap(metrics, paris)
+	assert.Equal(t, 300.0, h.Values[time.Monday][1])
+	assert.Equal(t, 200.0, h.Values[time.Monday][12])
+}
+
+func TestHeatmapSVG(t *testing.T) {
+	h := NewHeatmap(Metrics{"2024-04-01T10:00:00Z": {Timings: Timings{Total: 100}}}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, h.SVG(&buf))
+	assert.Contains(t, buf.String(), `<title>Monday 10:00 100ms</title>`)
+	assert.Contains(t, buf.String(), `fill="hsl(0,70%,50%)"`)
+	assert.Equal(t, 7*24, bytes.Count(buf.Bytes(), []byte("<rect")))
+}
@@ -0,0 +1,114 @@
+package updown
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// CompareHostsOptions selects the period over which monitoring locations are compared
+type CompareHostsOptions struct {
+	// Start and end of the period, in any format accepted by Metric.List
+	From string
+	To   string
+}
+
+// HostDelta is the performance of a check from a monitoring location, compared to the other ones
+type HostDelta struct {
+	// Key of the location in metrics grouped by host, e.g. "fra"
+	Node string
+	Host Host
+	// Average total response time
+	Average time.Duration
+	// Difference with the mean of all locations, positive when slower
+	Delta       time.Duration
+	Apdex       float64
+	FailureRate float64
+}
+
+// HostComparison compares the performance of a check across monitoring locations
+type HostComparison struct {
+	Token string
+	// Locations sorted from the fastest to the slowest
+	Hosts []HostDelta
+	// Mean and standard deviation of the response times of the locations
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+// Fastest gives the fastest location, if any
+func (c HostComparison) Fastest() (HostDelta, bool) {
+	if len(c.Hosts) == 0 {
+		return HostDelta{}, false
+	}
+	return c.Hosts[0], true
+}
+
+// Slowest gives the slowest location, if any
+func (c HostComparison) Slowest() (HostDelta, bool) {
+	if len(c.Hosts) == 0 {
+		return HostDelta{}, false
+	}
+	return c.Hosts[len(c.Hosts)-1], true
+}
+
+// CompareHosts compares the metrics of a check grouped by host. Each location weighs the same
+// in the mean, whatever its number of samples
+func CompareHosts(token string, metrics Metrics) HostComparison {
+	res := HostComparison{Token: token}
+	if len(metrics) == 0 {
+		return res
+	}
+
+	var sum float64
+	for node, m := range metrics {
+		var failureRate float64
+		if m.Requests.Samples > 0 {
+			failureRate = float64(m.Requests.Failures) / float64(m.Requests.Samples)
+		}
+		res.Hosts = append(res.Hosts, HostDelta{
+			Node:        node,
+			Host:        m.Host,
+			Average:     time.Duration(m.Timings.Total) * time.Millisecond,
+			Apdex:       m.Apdex,
+			FailureRate: failureRate,
+		})
+		sum += float64(m.Timings.Total)
+	}
+	sort.Slice(res.Hosts, func(i, j int) bool {
+		if res.Hosts[i].Average != res.Hosts[j].Average {
+			return res.Hosts[i].Average < res.Hosts[j].Average
+		}
+		return res.Hosts[i].Node < res.Hosts[j].Node
+	})
+
+	mean := sum / float64(len(res.Hosts))
+	var variance float64
+	for i, h := range res.Hosts {
+		ms := float64(h.Average / time.Millisecond)
+		variance += (ms - mean) * (ms - mean)
+		res.Hosts[i].Delta = time.Duration((ms - mean) * float64(time.Millisecond))
+	}
+	variance /= float64(len(res.Hosts))
+	res.Mean = time.Duration(mean * float64(time.Millisecond))
+	res.StdDev = time.Duration(math.Sqrt(variance) * float64(time.Millisecond))
+
+	return res
+}
+
+// CompareHosts compares the performance of a check across monitoring locations over a period,
+// to spot regional performance problems
+func (s *MetricService) CompareHosts(token string, opts CompareHostsOptions) (HostComparison, *http.Response, error) {
+	return s.CompareHostsCtx(context.Background(), token, opts)
+}
+
+// CompareHostsCtx is like CompareHosts, with a context
+func (s *MetricService) CompareHostsCtx(ctx context.Context, token string, opts CompareHostsOptions) (HostComparison, *http.Response, error) {
+	metrics, resp, err := s.ListCtx(ctx, token, "host", opts.From, opts.To)
+	if err != nil {
+		return HostComparison{}, resp, err
+	}
+	return CompareHosts(token, metrics), resp, nil
+}
@@ -0,0 +1,54 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareHosts(t *testing.T) {
+	metrics := Metrics{
+		"fra": {Timings: Timings{Total: 100}, Requests: Requests{Samples: 10, Failures: 1}, Host: Host{City: "Frankfurt"}},
+		"sgp": {Timings: Timings{Total: 400}, Requests: Requests{Samples: 10}},
+		"nyc": {Timings: Timings{Total: 100}, Requests: Requests{Samples: 10}},
+	}
+
+	res := CompareHosts("abcd", metrics)
+	require.Len(t, res.Hosts, 3)
+	assert.Equal(t, 200*time.Millisecond, res.Mean)
+	assert.Equal(t, "141.421356ms", res.StdDev.String())
+
+	fastest, ok := res.Fastest()
+	require.True(t, ok)
+	assert.Equal(t, "fra", fastest.Node)
+	assert.Equal(t, "Frankfurt", fastest.Host.City)
+	assert.Equal(t, -100*time.Millisecond, fastest.Delta)
+	assert.Equal(t, 0.1, fastest.FailureRate)
+
+	slowest, _ := res.Slowest()
+	assert.Equal(t, "sgp", slowest.Node)
+	assert.Equal(t, 200*time.Millisecond, slowest.Delta)
+
+	_, ok = CompareHosts("abcd", nil).Fastest()
+	assert.False(t, ok)
+}
+
+func TestMetricCompareHosts(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"fra":{"timings":{"total":120}},"sgp":{"timings":{"total":300}}}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	res, _, err := client.Metric.CompareHosts("abcd", CompareHostsOptions{From: "2024-01-01"})
+	require.NoError(t, err)
+	assert.Contains(t, query, "group=host")
+	assert.Equal(t, "abcd", res.Token)
+	assert.Equal(t, 210*time.Millisecond, res.Mean)
+}
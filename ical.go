@@ -0,0 +1,185 @@
+package updown
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseICal reads the events of an iCalendar (.ics) file as windows, e.g. to import
+// maintenance windows or holidays from a team calendar. All-day events span whole days
+// and floating times are read in the given location, UTC when nil. Events may end with
+// either DTEND or DURATION. Recurrence rules are not expanded
+func ParseICal(r io.Reader, loc *time.Location) ([]Window, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Window
+	var cur *Window
+	var duration *icalDuration
+	allDay := false
+	for _, line := range lines {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			cur, duration = &Window{}, nil
+		case name == "END" && value == "VEVENT" && cur != nil:
+			if cur.Start.IsZero() {
+				return nil, errors.New("ical: event without DTSTART")
+			}
+			if cur.End.IsZero() && duration != nil {
+				cur.End = duration.after(cur.Start)
+			}
+			if cur.End.IsZero() {
+				// Events without end last one day when all-day, else are instantaneous
+				cur.End = cur.Start
+				if allDay {
+					cur.End = cur.Start.AddDate(0, 0, 1)
+				}
+			}
+			res = append(res, *cur)
+			cur = nil
+		case (name == "DTSTART" || name == "DTEND") && cur != nil:
+			t, err := parseICalTime(params, value, loc)
+			if err != nil {
+				return nil, err
+			}
+			if name == "DTSTART" {
+				cur.Start, allDay = t, isICalDate(params, value)
+			} else {
+				cur.End = t
+			}
+		case name == "DURATION" && cur != nil:
+			d, err := parseICalDuration(value)
+			if err != nil {
+				return nil, err
+			}
+			duration = &d
+		}
+	}
+
+	return res, nil
+}
+
+// unfoldICal reads the logical lines of a calendar, continuation lines starting with a space
+func unfoldICal(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitICalLine splits a line such as "DTSTART;TZID=Europe/Paris:20240401T090000"
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+func parseICalTime(params map[string]string, value string, loc *time.Location) (time.Time, error) {
+	if tzid, ok := params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("ical: %w", err)
+		}
+		loc = l
+	}
+
+	switch {
+	case isICalDate(params, value):
+		return time.ParseInLocation("20060102", value, loc)
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+func isICalDate(params map[string]string, value string) bool {
+	return params["VALUE"] == "DATE" || len(value) == len("20060102")
+}
+
+// icalDuration is the value of a DURATION property, days and weeks being nominal so that
+// they keep the time of day across daylight saving time changes
+type icalDuration struct {
+	days int
+	time time.Duration
+}
+
+// after gives the end of an event starting at the given time
+func (d icalDuration) after(start time.Time) time.Time {
+	return start.AddDate(0, 0, d.days).Add(d.time)
+}
+
+// parseICalDuration parses a duration such as "PT1H30M", "P1DT12H" or "P2W"
+func parseICalDuration(value string) (icalDuration, error) {
+	var d icalDuration
+	invalid := fmt.Errorf("ical: invalid duration %q", value)
+
+	rest, negative := strings.CutPrefix(value, "-")
+	if !negative {
+		rest = strings.TrimPrefix(rest, "+")
+	}
+	rest, ok := strings.CutPrefix(rest, "P")
+	if !ok || rest == "" || strings.HasSuffix(rest, "T") {
+		return d, invalid
+	}
+
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' && !inTime {
+			inTime, rest = true, rest[1:]
+			continue
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return d, invalid
+		}
+		n, err := strconv.Atoi(rest[:i])
+		if err != nil {
+			return d, invalid
+		}
+		switch unit := rest[i]; {
+		case unit == 'W' && !inTime:
+			d.days += 7 * n
+		case unit == 'D' && !inTime:
+			d.days += n
+		case unit == 'H' && inTime:
+			d.time += time.Duration(n) * time.Hour
+		case unit == 'M' && inTime:
+			d.time += time.Duration(n) * time.Minute
+		case unit == 'S' && inTime:
+			d.time += time.Duration(n) * time.Second
+		default:
+			return d, invalid
+		}
+		rest = rest[i+1:]
+	}
+
+	if negative {
+		d.days, d.time = -d.days, -d.time
+	}
+	return d, nil
+}
@@ -0,0 +1,82 @@
+package updown
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Database maintenance\r\n" +
+	"DTSTART:20240403T100000Z\r\n" +
+	"DTEND:20240403T120000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Company\r\n" +
+	"  offsite\r\n" +
+	"DTSTART;VALUE=DATE:20240405\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	windows, err := ParseICal(strings.NewReader(testCalendar), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Window{
+		{time.Date(2024, time.April, 3, 10, 0, 0, 0, time.UTC), time.Date(2024, time.April, 3, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, time.April, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, time.April, 6, 0, 0, 0, 0, time.UTC)},
+	}, windows)
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nEND:VEVENT\n"), nil)
+	assert.Error(t, err)
+}
+
+func TestParseICalDuration(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	calendar := "BEGIN:VEVENT\r\n" +
+		"DTSTART:20240403T100000Z\r\n" +
+		"DURATION:PT1H30M\r\n" +
+		"END:VEVENT\r\n" +
+		// Days are nominal, keeping the time of day over the change to summer time
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Europe/Paris:20240330T090000\r\n" +
+		"DURATION:P1DT2H\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240408\r\n" +
+		"DURATION:P1W\r\n" +
+		"END:VEVENT\r\n"
+	windows, err := ParseICal(strings.NewReader(calendar), nil)
+	require.NoError(t, err)
+	require.Len(t, windows, 3)
+	assert.Equal(t, time.Date(2024, time.April, 3, 11, 30, 0, 0, time.UTC), windows[0].End)
+	assert.True(t, time.Date(2024, time.March, 31, 11, 0, 0, 0, paris).Equal(windows[1].End), windows[1].End)
+	assert.Equal(t, time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC), windows[2].End)
+
+	for _, value := range []string{"", "P", "PT", "1H", "PT1D", "P1H", "PTH"} {
+		_, err := ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:20240403T100000Z\nDURATION:"+value+"\nEND:VEVENT\n"), nil)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestBusinessHoursClosures(t *testing.T) {
+	windows, err := ParseICal(strings.NewReader(testCalendar), nil)
+	require.NoError(t, err)
+
+	hours := BusinessHours{Days: weekdays, Start: 9 * time.Hour, End: 17 * time.Hour, Closures: windows}
+	res := hours.Windows(
+		time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 6, 0, 0, 0, 0, time.UTC),
+	)
+	assert.Equal(t, []Window{
+		{time.Date(2024, time.April, 3, 9, 0, 0, 0, time.UTC), time.Date(2024, time.April, 3, 10, 0, 0, 0, time.UTC)},
+		{time.Date(2024, time.April, 3, 12, 0, 0, 0, time.UTC), time.Date(2024, time.April, 3, 17, 0, 0, 0, time.UTC)},
+		{time.Date(2024, time.April, 4, 9, 0, 0, 0, time.UTC), time.Date(2024, time.April, 4, 17, 0, 0, 0, time.UTC)},
+	}, res)
+}
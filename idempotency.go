@@ -0,0 +1,74 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// operations tells, for each operation of the API, if making it several times has the
+// same effect as making it once, so that it is safe to retry. Identifiers in paths are
+// replaced by *
+var operations = map[string]bool{
+	"GET checks":             true,
+	"GET checks/*":           true,
+	"POST checks":            false,
+	"PUT checks/*":           true,
+	"DELETE checks/*":        true,
+	"GET checks/*/downtimes": true,
+	"GET checks/*/metrics":   true,
+	"GET nodes":              true,
+	"GET nodes/*":            true,
+	"GET recipients":         true,
+	"POST recipients":        false,
+	"DELETE recipients/*":    true,
+	"GET status_pages":       true,
+	"GET status_pages/*":     true,
+	"POST status_pages":      false,
+	"PUT status_pages/*":     true,
+	"DELETE status_pages/*":  true,
+	"GET webhooks":           true,
+	"POST webhooks":          false,
+	"DELETE webhooks/*":      true,
+}
+
+type idempotentKey struct{}
+
+// ContextWithIdempotent marks the requests made with the returned context as safe to retry or
+// not, whatever their operation and the RetryNonIdempotent setting of the retry policy. It lets
+// callers retry the creation of a check they deduplicate on their side, e.g. by its alias, or
+// make sure a call is attempted only once
+func ContextWithIdempotent(ctx context.Context, idempotent bool) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, idempotent)
+}
+
+// retryable tells if a request may be retried after a network error or a 5xx status
+func (c *Client) retryable(req *http.Request) bool {
+	if idempotent, ok := req.Context().Value(idempotentKey{}).(bool); ok {
+		return idempotent
+	}
+	return c.retry.RetryNonIdempotent || c.isIdempotent(req)
+}
+
+// isIdempotent tells if the operation of a request is idempotent. Operations unknown to
+// the client, e.g. sent with Do, are classified by their method
+func (c *Client) isIdempotent(req *http.Request) bool {
+	if idempotent, ok := operations[req.Method+" "+c.operationPath(req)]; ok {
+		return idempotent
+	}
+	return isIdempotent(req.Method)
+}
+
+// operationPath gives the path of a request relative to the base URL, with identifiers
+// replaced by *, e.g. checks/*/downtimes
+func (c *Client) operationPath(req *http.Request) string {
+	path, ok := strings.CutPrefix(req.URL.Path, c.BaseURL.Path)
+	if !ok {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i += 2 {
+		segments[i] = "*"
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,61 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationIdempotency(t *testing.T) {
+	client := newTestClient(t, "https://example.com/api")
+	tests := []struct {
+		method, path string
+		idempotent   bool
+	}{
+		{"POST", "checks", false},
+		{"PUT", "checks/abcd", true},
+		{"DELETE", "checks/abcd", true},
+		{"GET", "checks/abcd/metrics?from=2024-01-01", true},
+		{"POST", "recipients", false},
+		{"DELETE", "webhooks/123", true},
+		// Unknown operations are classified by their method
+		{"POST", "checks/abcd/pause", false},
+		{"PATCH", "checks/abcd", false},
+		{"OPTIONS", "checks", true},
+	}
+	for _, tt := range tests {
+		req, err := client.NewRequest(tt.method, tt.path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, tt.idempotent, client.isIdempotent(req), "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestContextWithIdempotent(t *testing.T) {
+	// Creating a check is retried when the caller says it is safe
+	server, calls := flakyServer(t, 1)
+	client := newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+	check, _, err := client.Check.AddCtx(ContextWithIdempotent(context.Background(), true), CheckItem{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+	assert.Equal(t, 2, *calls)
+
+	// Deleting a check is attempted once when the caller says so
+	server, calls = flakyServer(t, 1)
+	client = newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+	_, resp, err := client.Check.RemoveCtx(ContextWithIdempotent(context.Background(), false), "abcd")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, *calls)
+
+	// The override also wins over the retry policy
+	policy := fastRetries
+	policy.RetryNonIdempotent = true
+	server, calls = flakyServer(t, 1)
+	client = newTestClient(t, server.URL, WithRetryPolicy(policy))
+	_, _, err = client.Check.AddCtx(ContextWithIdempotent(context.Background(), false), CheckItem{URL: "https://example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls)
+}
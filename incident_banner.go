@@ -0,0 +1,113 @@
+package updown
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// incidentBannerMark encloses the incident banners written to status page descriptions. It is an
+// invisible character, so that banners can be told apart from text written by users
+const incidentBannerMark = "\u2063"
+
+// IncidentBanner renders the text of the banner shown on a status page while some of its checks are down
+type IncidentBanner func(down []Check) string
+
+// DefaultIncidentBanner lists the checks which are down and since when
+func DefaultIncidentBanner(down []Check) string {
+	parts := make([]string, 0, len(down))
+	for _, check := range down {
+		name := check.Alias
+		if name == "" {
+			name = check.URL
+		}
+		if check.DownSince != "" {
+			name += " (down since " + check.DownSince + ")"
+		}
+		parts = append(parts, name)
+	}
+	return fmt.Sprintf("Ongoing incident affecting %s", strings.Join(parts, ", "))
+}
+
+// withIncidentBanner gives the description of a status page with the banner for the given down
+// checks, or without any banner when no check is down
+func withIncidentBanner(description string, down []Check, banner IncidentBanner) string {
+	if rest, ok := strings.CutPrefix(description, incidentBannerMark); ok {
+		if _, after, ok := strings.Cut(rest, incidentBannerMark); ok {
+			description = strings.TrimPrefix(after, "\n\n")
+		}
+	}
+	if len(down) == 0 {
+		return description
+	}
+
+	text := strings.ReplaceAll(banner(down), incidentBannerMark, "")
+	text = incidentBannerMark + "⚠️ " + text + incidentBannerMark
+	if description == "" {
+		return text
+	}
+	return text + "\n\n" + description
+}
+
+// SyncIncidentBanner shows an incident banner at the top of the description of a status page
+// while some of its checks are down, and removes it once they all recovered. The banner is
+// rendered with DefaultIncidentBanner when nil. It tells whether the page was updated
+func (s *StatusPageService) SyncIncidentBanner(token string, banner IncidentBanner) (bool, *http.Response, error) {
+	return s.SyncIncidentBannerCtx(context.Background(), token, banner)
+}
+
+// SyncIncidentBannerCtx is like SyncIncidentBanner, with a context
+func (s *StatusPageService) SyncIncidentBannerCtx(ctx context.Context, token string, banner IncidentBanner) (bool, *http.Response, error) {
+	if banner == nil {
+		banner = DefaultIncidentBanner
+	}
+
+	page, resp, err := s.GetCtx(ctx, token)
+	if err != nil {
+		return false, resp, err
+	}
+
+	checks, resp, err := s.client.Check.ListCtx(ctx)
+	if err != nil {
+		return false, resp, err
+	}
+	byToken := make(map[string]Check, len(checks))
+	for _, check := range checks {
+		byToken[check.Token] = check
+	}
+
+	var down []Check
+	for _, t := range page.Checks {
+		if check, ok := byToken[t]; ok && check.Down {
+			down = append(down, check)
+		}
+	}
+
+	description := withIncidentBanner(page.Description, down, banner)
+	if description == page.Description {
+		return false, resp, nil
+	}
+
+	// The description is always sent, for an empty one to clear the banner
+	data := struct {
+		StatusPageItem
+		Description string `json:"description"`
+	}{
+		StatusPageItem: StatusPageItem{
+			Checks:     page.Checks,
+			Name:       page.Name,
+			Visibility: page.Visibility,
+			AccessKey:  page.AccessKey,
+		},
+		Description: description,
+	}
+	req, err := s.client.NewRequestCtx(ctx, "PUT", pathForStatusPageToken(token), data)
+	if err != nil {
+		return false, nil, err
+	}
+	if _, resp, err = do[StatusPage](s.client, req); err != nil {
+		return false, resp, err
+	}
+	return true, resp, nil
+}
@@ -0,0 +1,82 @@
+package updown
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithIncidentBanner(t *testing.T) {
+	down := []Check{{Alias: "Billing API", DownSince: "2024-04-01T10:00:00Z"}, {URL: "https://example.com"}}
+	mark := incidentBannerMark
+
+	desc := withIncidentBanner("Our services", down, DefaultIncidentBanner)
+	assert.Equal(t, mark+"⚠️ Ongoing incident affecting Billing API (down since 2024-04-01T10:00:00Z), https://example.com"+mark+"\n\nOur services", desc)
+
+	// The banner is replaced rather than stacked
+	desc = withIncidentBanner(desc, down[:1], DefaultIncidentBanner)
+	assert.Equal(t, mark+"⚠️ Ongoing incident affecting Billing API (down since 2024-04-01T10:00:00Z)"+mark+"\n\nOur services", desc)
+
+	// And removed on recovery
+	assert.Equal(t, "Our services", withIncidentBanner(desc, nil, DefaultIncidentBanner))
+
+	// Empty descriptions only hold the banner
+	custom := func(down []Check) string { return "We are investigating\n\nUpdates to follow" }
+	desc = withIncidentBanner("", down, custom)
+	assert.Equal(t, mark+"⚠️ We are investigating\n\nUpdates to follow"+mark, desc)
+	assert.Equal(t, "", withIncidentBanner(desc, nil, custom))
+
+	// Text written by users is kept, even when it looks like a banner
+	assert.Equal(t, "⚠️ Maintenance Sunday", withIncidentBanner("⚠️ Maintenance Sunday", nil, custom))
+	desc = withIncidentBanner("⚠️ Maintenance Sunday", down, custom)
+	assert.Equal(t, "⚠️ Maintenance Sunday", withIncidentBanner(desc, nil, custom))
+}
+
+func TestSyncIncidentBanner(t *testing.T) {
+	down := true
+	description := ""
+	var updates []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/status_pages":
+			json.NewEncoder(w).Encode([]StatusPage{{Token: "page", Name: "Status", Description: description, Checks: []string{"abcd"}}})
+		case r.Method == "GET" && r.URL.Path == "/checks":
+			json.NewEncoder(w).Encode([]Check{{Token: "abcd", Alias: "API", Down: down}})
+		case r.Method == "PUT" && r.URL.Path == "/status_pages/page":
+			var update map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			updates = append(updates, update)
+			description, _ = update["description"].(string)
+			json.NewEncoder(w).Encode(StatusPage{Token: "page", Description: description})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	updated, _, err := client.StatusPage.SyncIncidentBanner("page", nil)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Contains(t, description, "Ongoing incident affecting API")
+
+	// Nothing changed
+	updated, _, err = client.StatusPage.SyncIncidentBanner("page", nil)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.Len(t, updates, 1)
+
+	// Clearing the banner sends the empty description
+	down = false
+	updated, _, err = client.StatusPage.SyncIncidentBanner("page", nil)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	require.Len(t, updates, 2)
+	assert.Contains(t, updates[1], "description")
+	assert.Equal(t, "", description)
+	assert.Equal(t, "Status", updates[1]["name"])
+}
@@ -0,0 +1,75 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// LatencyBudgets maps check tokens to their maximum acceptable response time
+type LatencyBudgets map[string]time.Duration
+
+// LatencyRegression reports a check whose response time went over its budget
+type LatencyRegression struct {
+	Token  string
+	Budget time.Duration
+	// Average total response time over the period, weighted by samples
+	Average time.Duration
+	// Keys of the metrics over budget, sorted
+	Over []string
+}
+
+// Evaluate compares metrics grouped by time of a check against its budget. It tells
+// whether the average response time or any of the metrics went over budget
+func (b LatencyBudgets) Evaluate(token string, metrics Metrics) (LatencyRegression, bool) {
+	res := LatencyRegression{Token: token, Budget: b[token]}
+	if res.Budget <= 0 || len(metrics) == 0 {
+		return res, false
+	}
+
+	var total, samples int
+	for key, m := range metrics {
+		weight := max(1, m.Requests.Samples)
+		total += m.Timings.Total * weight
+		samples += weight
+
+		if time.Duration(m.Timings.Total)*time.Millisecond > res.Budget {
+			res.Over = append(res.Over, key)
+		}
+	}
+	sort.Strings(res.Over)
+	res.Average = time.Duration(total/samples) * time.Millisecond
+
+	return res, res.Average > res.Budget || len(res.Over) > 0
+}
+
+// LatencyReport evaluates the checks having a budget over a period, e.g. the last week,
+// and lists the ones which went over budget
+func (s *MetricService) LatencyReport(budgets LatencyBudgets, from, to string) ([]LatencyRegression, *http.Response, error) {
+	return s.LatencyReportCtx(context.Background(), budgets, from, to)
+}
+
+// LatencyReportCtx is like LatencyReport, with a context
+func (s *MetricService) LatencyReportCtx(ctx context.Context, budgets LatencyBudgets, from, to string) ([]LatencyRegression, *http.Response, error) {
+	tokens := make([]string, 0, len(budgets))
+	for token := range budgets {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+
+	var res []LatencyRegression
+	var resp *http.Response
+	for _, token := range tokens {
+		metrics, r, err := s.ListCtx(ctx, token, "time", from, to)
+		resp = r
+		if err != nil {
+			return nil, resp, err
+		}
+		if regression, over := budgets.Evaluate(token, metrics); over {
+			res = append(res, regression)
+		}
+	}
+
+	return res, resp, nil
+}
@@ -0,0 +1,29 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyBudgetsEvaluate(t *testing.T) {
+	budgets := LatencyBudgets{"abcd": 500 * time.Millisecond}
+	metrics := Metrics{
+		"2024-04-01T00:00:00Z": {Timings: Timings{Total: 300}, Requests: Requests{Samples: 3}},
+		"2024-04-01T01:00:00Z": {Timings: Timings{Total: 700}, Requests: Requests{Samples: 1}},
+	}
+
+	res, over := budgets.Evaluate("abcd", metrics)
+	assert.True(t, over)
+	assert.Equal(t, 400*time.Millisecond, res.Average)
+	assert.Equal(t, []string{"2024-04-01T01:00:00Z"}, res.Over)
+
+	budgets["abcd"] = time.Second
+	_, over = budgets.Evaluate("abcd", metrics)
+	assert.False(t, over)
+
+	// Checks without budget are never over budget
+	_, over = budgets.Evaluate("efgh", metrics)
+	assert.False(t, over)
+}
@@ -0,0 +1,47 @@
+package updown
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// WithLogger logs every API call to logger, with its method, path, status, latency, number of retries and request ID.
+// Failed calls are logged with the warning level
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// logCall logs an API call after its attempts
+func (c *Client) logCall(req *http.Request, resp *http.Response, err error, attempts RetryInfo) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("latency", attempts.Elapsed),
+		slog.Int("retries", attempts.Retries()),
+	}
+	if id := req.Header.Get(RequestIDHeader); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	ctx := req.Context()
+	if ctx.Err() != nil {
+		// Still log calls which were cancelled
+		ctx = context.WithoutCancel(ctx)
+	}
+	c.logger.LogAttrs(ctx, level, "updown API call", attrs...)
+}
@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 )
@@ -61,6 +62,11 @@ type MetricService struct {
 // List lists metrics available for a check identified by a taken, grouped by the given group
 // (host|time) over a period
 func (s *MetricService) List(token, group, from, to string) (Metrics, *http.Response, error) {
+	return s.ListCtx(context.Background(), token, group, from, to)
+}
+
+// ListCtx is like List, with a context
+func (s *MetricService) ListCtx(ctx context.Context, token, group, from, to string) (Metrics, *http.Response, error) {
 	u, _ := url.Parse(pathForToken(token) + "/metrics")
 	q := u.Query()
 	q.Add("group", group)
@@ -74,16 +80,10 @@ func (s *MetricService) List(token, group, from, to string) (Metrics, *http.Resp
 	}
 	u.RawQuery = q.Encode()
 
-	req, err := s.client.NewRequest("GET", u.String(), nil)
+	req, err := s.client.NewRequestCtx(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var res Metrics
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return res, resp, err
+	return do[Metrics](s.client, req)
 }
@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -26,42 +27,45 @@ type Nodes map[string]NodeDetails
 
 // List gets the nodes performing checks
 func (s *NodeService) List() (Nodes, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "nodes", nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return s.ListCtx(context.Background())
+}
 
-	var res Nodes
-	resp, err := s.client.Do(req, &res)
+// ListCtx is like List, with a context
+func (s *NodeService) ListCtx(ctx context.Context) (Nodes, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "GET", "nodes", nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return res, resp, err
+	return do[Nodes](s.client, req)
 }
 
 // ListIPv4 gets the list of IPv4 performing checks
 func (s *NodeService) ListIPv4() (IPs, *http.Response, error) {
-	return s.genericIPList("4")
+	return s.ListIPv4Ctx(context.Background())
+}
+
+// ListIPv4Ctx is like ListIPv4, with a context
+func (s *NodeService) ListIPv4Ctx(ctx context.Context) (IPs, *http.Response, error) {
+	return s.genericIPList(ctx, "4")
 }
 
 // ListIPv6 gets the list of IPv6 performing checks
 func (s *NodeService) ListIPv6() (IPs, *http.Response, error) {
-	return s.genericIPList("6")
+	return s.ListIPv6Ctx(context.Background())
+}
+
+// ListIPv6Ctx is like ListIPv6, with a context
+func (s *NodeService) ListIPv6Ctx(ctx context.Context) (IPs, *http.Response, error) {
+	return s.genericIPList(ctx, "6")
 }
 
 // genericIPList get the list of IPv4 or IPv6 IPs performing checks
-func (s *NodeService) genericIPList(version string) (IPs, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "nodes/ipv"+version, nil)
+func (s *NodeService) genericIPList(ctx context.Context, version string) (IPs, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "GET", "nodes/ipv"+version, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var res IPs
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return res, resp, err
+	return do[IPs](s.client, req)
 }
@@ -0,0 +1,83 @@
+package updown
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client) error
+
+// WithHTTPClient sets the HTTP client used to communicate with the API. By default, one is built by
+// NewHTTPClient with the default TransportConfig
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("updown: nil HTTP client")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// WithTransport connects to the API as described by the given configuration
+func WithTransport(cfg TransportConfig) Option {
+	return WithHTTPClient(NewHTTPClient(cfg))
+}
+
+// WithBaseURL sets the base URL of the API, https://updown.io/api/ by default. It lets requests
+// go through a gateway proxying updown.io, e.g. https://gateway.internal/updown/api/
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := ParseBaseURL(baseURL)
+		if err != nil {
+			return err
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// ParseBaseURL validates an API base URL, which must be an absolute http or https URL. A trailing
+// slash is added to its path if missing, so request paths are resolved under it
+func ParseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("updown: base URL %q must use http or https", baseURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("updown: base URL %q has no host", baseURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		c.UserAgent = ua
+		return nil
+	}
+}
+
+// WithTimeout limits the time taken by each request, including reading the response body,
+// DefaultTimeout by default. Zero disables it. Each retry gets its own timeout.
+// See ContextWithRequestTimeout to override it for some calls
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("updown: negative timeout")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientDefaults(t *testing.T) {
+	client, err := NewClient("key")
+	require.NoError(t, err)
+	assert.Equal(t, defaultBaseURL, client.BaseURL.String())
+	assert.Equal(t, userAgent, client.UserAgent)
+	assert.NotSame(t, http.DefaultClient, client.client)
+	transport := client.client.Transport.(*http.Transport)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.Equal(t, DefaultTimeout, client.timeout)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var ua string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.Header.Get("User-Agent")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	client, err := NewClient("key",
+		WithTimeout(time.Second),
+		WithHTTPClient(httpClient),
+		WithBaseURL(server.URL+"/"),
+		WithUserAgent("my-app/1.0"),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "my-app/1.0", ua)
+
+	// The timeout applies whatever the order of options, without altering the given client
+	assert.Equal(t, time.Second, client.timeout)
+	assert.Same(t, httpClient, client.client)
+	assert.Zero(t, httpClient.Timeout)
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	_, err := NewClient("key", WithBaseURL("://nope"))
+	assert.Error(t, err)
+
+	_, err = NewClient("key", WithHTTPClient(nil))
+	assert.Error(t, err)
+
+	_, err = NewClient("key", WithTimeout(-time.Second))
+	assert.Error(t, err)
+}
+
+func TestWithBaseURL(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	// The missing trailing slash is added
+	client, err := NewClient("key", WithBaseURL(server.URL+"/updown/api"))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/updown/api/", client.BaseURL.String())
+
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "/updown/api/checks", path)
+
+	for _, invalid := range []string{"updown.io/api/", "ftp://updown.io/api/", "https:///api/", "://nope"} {
+		_, err = NewClient("key", WithBaseURL(invalid))
+		assert.Error(t, err, invalid)
+	}
+
+	// Base URLs set by hand must have a trailing slash
+	client.BaseURL.Path = "/updown/api"
+	_, err = client.NewRequest("GET", "checks", nil)
+	assert.Error(t, err)
+}
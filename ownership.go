@@ -0,0 +1,50 @@
+package updown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ownerTag matches the owner tag recorded in a check alias, e.g. "Billing API [owner:payments]"
+var ownerTag = regexp.MustCompile(`\s*\[owner:([^\]]+)\]`)
+
+// OwnerOf finds the owner (team, email...) recorded in the alias of a check
+func OwnerOf(check Check) (owner string, found bool) {
+	m := ownerTag.FindStringSubmatch(check.Alias)
+	if m == nil {
+		return "", false
+	}
+	return strings.TrimSpace(m[1]), true
+}
+
+// AliasWithOwner records an owner in an alias, replacing the previous owner if any.
+// An empty owner removes the tag from the alias
+func AliasWithOwner(alias, owner string) string {
+	alias = strings.TrimSpace(ownerTag.ReplaceAllString(alias, ""))
+	if owner == "" {
+		return alias
+	}
+	if alias == "" {
+		return "[owner:" + owner + "]"
+	}
+	return alias + " [owner:" + owner + "]"
+}
+
+// OwnerRoutes routes checks to a destination (email, Slack channel, webhook URL...)
+// based on their owner
+type OwnerRoutes struct {
+	// Destinations by owner
+	Routes map[string]string
+	// Destination for checks without owner, or with an unknown owner
+	Default string
+}
+
+// Route gives the destination for events related to the given check
+func (r OwnerRoutes) Route(check Check) string {
+	if owner, found := OwnerOf(check); found {
+		if dest, ok := r.Routes[owner]; ok {
+			return dest
+		}
+	}
+	return r.Default
+}
@@ -0,0 +1,34 @@
+package updown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerOf(t *testing.T) {
+	owner, found := OwnerOf(Check{Alias: "Billing API [owner:payments]"})
+	assert.True(t, found)
+	assert.Equal(t, "payments", owner)
+
+	_, found = OwnerOf(Check{Alias: "Billing API"})
+	assert.False(t, found)
+}
+
+func TestAliasWithOwner(t *testing.T) {
+	assert.Equal(t, "Billing API [owner:payments]", AliasWithOwner("Billing API", "payments"))
+	assert.Equal(t, "Billing API [owner:ops]", AliasWithOwner("Billing API [owner:payments]", "ops"))
+	assert.Equal(t, "Billing API", AliasWithOwner("Billing API [owner:payments]", ""))
+	assert.Equal(t, "[owner:ops]", AliasWithOwner("", "ops"))
+}
+
+func TestOwnerRoutes(t *testing.T) {
+	routes := OwnerRoutes{
+		Routes:  map[string]string{"payments": "#payments-alerts"},
+		Default: "#ops",
+	}
+
+	assert.Equal(t, "#payments-alerts", routes.Route(Check{Alias: "Billing API [owner:payments]"}))
+	assert.Equal(t, "#ops", routes.Route(Check{Alias: "Search [owner:search]"}))
+	assert.Equal(t, "#ops", routes.Route(Check{Alias: "Search"}))
+}
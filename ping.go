@@ -0,0 +1,79 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PingFailure tells why the API could not be pinged
+type PingFailure string
+
+const (
+	// The API key is missing, invalid or revoked
+	PingBadKey PingFailure = "bad API key"
+	// The API could not be reached, e.g. because of DNS, proxy or firewall issues
+	PingUnreachable PingFailure = "API unreachable"
+	// The API answered with another error
+	PingAPIError PingFailure = "API error"
+)
+
+// PingError reports a failed ping, see Client.Ping
+type PingError struct {
+	Failure PingFailure
+	Err     error
+}
+
+func (e *PingError) Error() string {
+	return fmt.Sprintf("updown: ping failed, %s: %v", e.Failure, e.Err)
+}
+
+func (e *PingError) Unwrap() error {
+	return e.Err
+}
+
+// Ping verifies that the API can be reached and that the API key is valid, e.g. when starting
+// an application. It returns a *PingError otherwise
+func (c *Client) Ping() error {
+	return c.PingCtx(context.Background())
+}
+
+// PingCtx is like Ping, with a context
+func (c *Client) PingCtx(ctx context.Context) error {
+	if c.APIKey == "" {
+		return &PingError{Failure: PingBadKey, Err: errors.New("no API key")}
+	}
+
+	// The short list of IPv4 of the nodes still requires a valid API key, and is discarded
+	// rather than decoded
+	req, err := c.NewRequestCtx(context.WithValue(ctx, liveKey{}, true), "GET", "nodes/ipv4", nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(req, io.Discard)
+
+	var netErr *NetworkError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrUnauthorized):
+		return &PingError{Failure: PingBadKey, Err: err}
+	case errors.As(err, &netErr), errors.Is(err, ErrCircuitOpen):
+		return &PingError{Failure: PingUnreachable, Err: err}
+	case ctx.Err() != nil:
+		return err
+	default:
+		return &PingError{Failure: PingAPIError, Err: err}
+	}
+}
+
+type liveKey struct{}
+
+// isLive tells if a request must be answered by the API itself, rather than by the
+// response cache, ETags or stale data
+func isLive(req *http.Request) bool {
+	live, _ := req.Context().Value(liveKey{}).(bool)
+	return live
+}
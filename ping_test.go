@@ -0,0 +1,85 @@
+package updown
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/nodes/ipv4", r.URL.Path)
+		switch r.Header.Get("X-API-KEY") {
+		case "key":
+			w.Write([]byte(`["198.51.100.1"]`))
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Invalid API key"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	require.NoError(t, client.Ping())
+
+	failure := func(err error) PingFailure {
+		var pingErr *PingError
+		require.True(t, errors.As(err, &pingErr), err)
+		return pingErr.Failure
+	}
+
+	client.APIKey = "wrong"
+	err := client.Ping()
+	assert.Equal(t, PingBadKey, failure(err))
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	client.APIKey = ""
+	assert.Equal(t, PingBadKey, failure(client.Ping()))
+
+	client.APIKey = "broken"
+	assert.Equal(t, PingAPIError, failure(client.Ping()))
+
+	server.Close()
+	client.APIKey = "key"
+	assert.Equal(t, PingUnreachable, failure(client.Ping()))
+}
+
+func TestPingBypassesCaches(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`["198.51.100.1"]`))
+	}))
+
+	client := newTestClient(t, server.URL, WithETagCache(), WithResponseCache(NewMemoryResponseCache(), time.Minute))
+	client.ServeStale = true
+	_, _, err := client.Node.ListIPv4()
+	require.NoError(t, err)
+	require.NoError(t, client.Ping())
+
+	// Stale or cached data does not make a failing API look reachable
+	failing = true
+	var pingErr *PingError
+	require.ErrorAs(t, client.Ping(), &pingErr)
+	assert.Equal(t, PingAPIError, pingErr.Failure)
+
+	server.Close()
+	require.ErrorAs(t, client.Ping(), &pingErr)
+	assert.Equal(t, PingUnreachable, pingErr.Failure)
+
+	// While the other calls still get the cached list
+	ips, _, err := client.Node.ListIPv4()
+	require.NoError(t, err)
+	assert.Len(t, ips, 1)
+}
@@ -0,0 +1,56 @@
+package updown
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which buffers are not kept for reuse, so that a
+// single large request does not stay in memory. Larger responses are not buffered at all
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// encodeJSON encodes v in a pooled buffer, and returns a copy of the result which stays
+// valid once the buffer is reused
+func encodeJSON(v interface{}) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// unmarshalJSON decodes data into v, reporting empty and truncated data as io.EOF and
+// io.ErrUnexpectedEOF like a json.Decoder reading from the response would
+func unmarshalJSON(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	var syntax *json.SyntaxError
+	switch {
+	case err == nil || !errors.As(err, &syntax):
+		return err
+	case len(bytes.TrimSpace(data)) == 0:
+		return io.EOF
+	case syntax.Offset >= int64(len(data)):
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package updown
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchChecks gives the body of a list of n checks, as returned by the API
+func benchChecks(n int) string {
+	return "[" + strings.TrimSuffix(strings.Repeat(`{"token":"abcd","url":"https://example.com","alias":"Example",`+
+		`"last_status":200,"uptime":99.98,"down":false,"period":60,"apdex_t":0.5,"enabled":true,"published":true,`+
+		`"ssl":{"tested_at":"2024-01-01T10:00:00Z","expires_at":"2024-06-01T10:00:00Z","valid":true}},`, n), ",") + "]"
+}
+
+// staticTransport answers every request with the same body, without network
+type staticTransport string
+
+func (t staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{"Content-Type": {mediaType}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(t))),
+		ContentLength: int64(len(t)),
+		Request:       req,
+	}, nil
+}
+
+func TestNewRequestBodyOutlivesPool(t *testing.T) {
+	client := newTestClient(t, "https://example.com")
+	first, err := client.NewRequest("POST", "checks", CheckItem{URL: "https://first.example.com"})
+	require.NoError(t, err)
+	_, err = client.NewRequest("POST", "checks", CheckItem{URL: "https://second.example.com"})
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(first.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "first.example.com")
+
+	// The body can be read again for retries
+	rewound, err := first.GetBody()
+	require.NoError(t, err)
+	again, err := io.ReadAll(rewound)
+	require.NoError(t, err)
+	assert.Equal(t, body, again)
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	var check Check
+	assert.ErrorIs(t, unmarshalJSON([]byte(" \n"), &check), io.EOF)
+	assert.ErrorIs(t, unmarshalJSON([]byte(`{"token":`), &check), io.ErrUnexpectedEOF)
+	assert.Error(t, unmarshalJSON([]byte(`{"token":}`), &check))
+	assert.NoError(t, unmarshalJSON([]byte(`{"token":"abcd"}`), &check))
+	assert.Equal(t, "abcd", check.Token)
+}
+
+func newBenchClient(b *testing.B, body string) *Client {
+	client, err := NewClient("key", WithHTTPClient(&http.Client{Transport: staticTransport(body)}))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return client
+}
+
+func BenchmarkCheckList(b *testing.B) {
+	client := newBenchClient(b, benchChecks(100))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := client.Check.List(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCheckListLarge lists checks from a multi-MB body, which is decoded as it is read
+func BenchmarkCheckListLarge(b *testing.B) {
+	client := newBenchClient(b, benchChecks(20000))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := client.Check.List(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckAdd(b *testing.B) {
+	client := newBenchClient(b, `{"token":"abcd","url":"https://example.com"}`)
+	item := CheckItem{URL: "https://example.com", Alias: "Example", Period: 60, CustomHeaders: map[string]string{"X-Test": "1"}}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := client.Check.Add(item); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCheckGet(b *testing.B) {
+	client := newBenchClient(b, `{"token":"abcd","url":"https://example.com"}`)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := client.Check.Get("abcd"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ProbeResult holds what a local HEAD request tells about a monitored URL, complementing
+// the remote view of updown for diagnostics
+type ProbeResult struct {
+	URL        string
+	StatusCode int
+	// Server response header
+	Server string
+	// Target of the redirection, if any
+	RedirectTo string
+	// HTTP version, e.g. HTTP/2.0
+	Proto    string
+	ProbedAt time.Time
+	// Error preventing the probe, if any
+	Error string
+}
+
+// DefaultProbeTimeout is the time given to a monitored URL to answer a probe by default
+const DefaultProbeTimeout = 10 * time.Second
+
+// Prober probes monitored URLs from the local network
+type Prober struct {
+	// HTTP client used for probes, http.DefaultClient by default. Redirections are never followed
+	HTTPClient *http.Client
+	// Maximum time of a probe, DefaultProbeTimeout by default, so that a host which never
+	// answers does not hold ProbeChecks up
+	Timeout time.Duration
+}
+
+// Probe performs a HEAD request to the given URL
+func (p Prober) Probe(ctx context.Context, rawURL string) (ProbeResult, error) {
+	res := ProbeResult{URL: rawURL, ProbedAt: time.Now()}
+
+	httpClient := http.DefaultClient
+	if p.HTTPClient != nil {
+		httpClient = p.HTTPClient
+	}
+	noRedirect := *httpClient
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", rawURL, nil)
+	if err != nil {
+		return res, err
+	}
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return res, err
+	}
+	resp.Body.Close()
+
+	res.StatusCode = resp.StatusCode
+	res.Server = resp.Header.Get("Server")
+	res.Proto = resp.Proto
+	if loc, err := resp.Location(); err == nil {
+		res.RedirectTo = loc.String()
+	}
+	return res, nil
+}
+
+// ProbeChecks probes the URL of every HTTP and HTTPS check, by check token. Failed probes
+// are reported in the Error field of their result
+func (p Prober) ProbeChecks(ctx context.Context, checks []Check) map[string]ProbeResult {
+	res := make(map[string]ProbeResult, len(checks))
+	for _, check := range checks {
+		u, err := url.Parse(check.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		result, err := p.Probe(ctx, check.URL)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		res[check.Token] = result
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProberProbeChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "HEAD", r.Method)
+		w.Header().Set("Server", "nginx")
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		}
+	}))
+	defer server.Close()
+
+	results := Prober{}.ProbeChecks(context.Background(), []Check{
+		{Token: "new", URL: server.URL + "/new"},
+		{Token: "old", URL: server.URL + "/old"},
+		{Token: "down", URL: "http://127.0.0.1:1"},
+		{Token: "ping", URL: "8.8.8.8", Type: "icmp"},
+	})
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, http.StatusOK, results["new"].StatusCode)
+	assert.Equal(t, "nginx", results["new"].Server)
+	assert.Equal(t, "HTTP/1.1", results["new"].Proto)
+
+	assert.Equal(t, http.StatusMovedPermanently, results["old"].StatusCode)
+	assert.Equal(t, server.URL+"/new", results["old"].RedirectTo)
+
+	assert.NotEmpty(t, results["down"].Error)
+}
+
+func TestProberTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-hang
+	}))
+	defer server.Close()
+	defer close(hang)
+
+	_, err := Prober{Timeout: 50 * time.Millisecond}.Probe(context.Background(), server.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
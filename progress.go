@@ -0,0 +1,35 @@
+package updown
+
+// Progress receives the progress of bulk operations, to render progress bars or
+// emit progress metrics
+type Progress interface {
+	// OnStart is called once before the first operation
+	OnStart(total int)
+	// OnItem is called after each operation, with the error it returned if any
+	OnItem(key string, done, total int, err error)
+	// OnDone is called once when the run stops, with the error stopping it if any
+	OnDone(done, total int, err error)
+}
+
+// ProgressFunc adapts a function to the Progress interface. It is called after each
+// successful operation
+type ProgressFunc func(done, total int)
+
+// OnStart does nothing
+func (f ProgressFunc) OnStart(total int) {}
+
+// OnItem calls f when the operation succeeded
+func (f ProgressFunc) OnItem(key string, done, total int, err error) {
+	if err == nil {
+		f(done, total)
+	}
+}
+
+// OnDone does nothing
+func (f ProgressFunc) OnDone(done, total int, err error) {}
+
+type noProgress struct{}
+
+func (noProgress) OnStart(total int)                             {}
+func (noProgress) OnItem(key string, done, total int, err error) {}
+func (noProgress) OnDone(done, total int, err error)             {}
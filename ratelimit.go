@@ -0,0 +1,72 @@
+package updown
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the rate limit of the API, as reported by the last response
+type RateLimit struct {
+	// Number of requests allowed in the current window
+	Limit int
+	// Number of requests left in the current window
+	Remaining int
+	// When the current window resets
+	Reset time.Time
+}
+
+// ParseRateLimit reads the rate limit headers of a response. Both X-RateLimit-* and
+// RateLimit-* headers are understood, the reset being given as a Unix timestamp or as
+// a number of seconds
+func ParseRateLimit(resp *http.Response) (RateLimit, bool) {
+	if resp == nil {
+		return RateLimit{}, false
+	}
+
+	var rl RateLimit
+	found := false
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		limit, errLimit := strconv.Atoi(resp.Header.Get(prefix + "Limit"))
+		remaining, errRemaining := strconv.Atoi(resp.Header.Get(prefix + "Remaining"))
+		if errLimit != nil || errRemaining != nil {
+			continue
+		}
+		rl.Limit, rl.Remaining, found = limit, remaining, true
+
+		if reset, err := strconv.ParseInt(resp.Header.Get(prefix+"Reset"), 10, 64); err == nil {
+			// Small values are delays rather than timestamps
+			if reset < 1e9 {
+				rl.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+			} else {
+				rl.Reset = time.Unix(reset, 0)
+			}
+		}
+		break
+	}
+	return rl, found
+}
+
+// rateLimitState keeps the last rate limit reported by the API
+type rateLimitState struct {
+	mu    sync.RWMutex
+	limit RateLimit
+	has   bool
+}
+
+// RateLimit gives the rate limit reported by the last response carrying rate limit
+// headers, to throttle before hitting 429 responses. It tells if any was received yet
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.rate.mu.RLock()
+	defer c.rate.mu.RUnlock()
+	return c.rate.limit, c.rate.has
+}
+
+func (c *Client) recordRateLimit(resp *http.Response) {
+	if rl, ok := ParseRateLimit(resp); ok {
+		c.rate.mu.Lock()
+		c.rate.limit, c.rate.has = rl, true
+		c.rate.mu.Unlock()
+	}
+}
@@ -0,0 +1,62 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	_, found := ParseRateLimit(resp)
+	assert.False(t, found)
+
+	resp.Header.Set("X-RateLimit-Limit", "100")
+	resp.Header.Set("X-RateLimit-Remaining", "42")
+	resp.Header.Set("X-RateLimit-Reset", "1712000000")
+	rl, found := ParseRateLimit(resp)
+	assert.True(t, found)
+	assert.Equal(t, RateLimit{Limit: 100, Remaining: 42, Reset: time.Unix(1712000000, 0)}, rl)
+
+	resp = &http.Response{Header: http.Header{}}
+	resp.Header.Set("RateLimit-Limit", "100")
+	resp.Header.Set("RateLimit-Remaining", "0")
+	resp.Header.Set("RateLimit-Reset", "30")
+	rl, found = ParseRateLimit(resp)
+	assert.True(t, found)
+	assert.Equal(t, 0, rl.Remaining)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), rl.Reset, time.Second)
+}
+
+func TestClientRateLimit(t *testing.T) {
+	remaining := "10"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if remaining != "" {
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Header().Set("X-RateLimit-Remaining", remaining)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	_, found := client.RateLimit()
+	assert.False(t, found)
+
+	_, _, err := client.Check.List()
+	require.NoError(t, err)
+	rl, found := client.RateLimit()
+	assert.True(t, found)
+	assert.Equal(t, 10, rl.Remaining)
+
+	// Responses without headers keep the last known rate limit
+	remaining = ""
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	rl, _ = client.RateLimit()
+	assert.Equal(t, 10, rl.Remaining)
+}
@@ -0,0 +1,64 @@
+package updown
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type rawJSONKey struct{}
+
+// WithRawJSON keeps the body of the responses returned by the client readable after decoding,
+// so fields not modeled by this package can be read, see RawJSON
+func WithRawJSON() Option {
+	return func(c *Client) error {
+		c.keepRaw = true
+		return nil
+	}
+}
+
+// ContextWithRawJSON stores the raw JSON body of the responses to the requests made with the
+// returned context into raw, alongside the decoded data
+func ContextWithRawJSON(ctx context.Context, raw *json.RawMessage) context.Context {
+	return context.WithValue(ctx, rawJSONKey{}, raw)
+}
+
+// RawJSON reads the raw JSON body of a response returned by a client created with WithRawJSON.
+// The body stays readable
+func RawJSON(resp *http.Response) (json.RawMessage, error) {
+	if resp == nil || resp.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// decode decodes the body of a response into v, keeping its raw JSON when asked to
+func (c *Client) decode(req *http.Request, response *http.Response, v interface{}) error {
+	raw, capture := req.Context().Value(rawJSONKey{}).(*json.RawMessage)
+	if !c.keepRaw && !capture {
+		return decodeResponse(response, v)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("updown: reading response: %w", err)
+	}
+	if capture {
+		*raw = data
+	}
+	if c.keepRaw {
+		response.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	decoded := *response
+	decoded.Body = io.NopCloser(bytes.NewReader(data))
+	return decodeResponse(&decoded, v)
+}
@@ -0,0 +1,48 @@
+package updown
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawJSON(t *testing.T) {
+	body := `{"token":"abcd","new_field":{"enabled":true}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	// Per call
+	client := newTestClient(t, server.URL)
+	var raw json.RawMessage
+	check, _, err := client.Check.GetCtx(ContextWithRawJSON(context.Background(), &raw), "abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+	assert.JSONEq(t, body, string(raw))
+
+	// Per client
+	client = newTestClient(t, server.URL, WithRawJSON())
+	check, resp, err := client.Check.Get("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+
+	for i := 0; i < 2; i++ {
+		raw, err = RawJSON(resp)
+		require.NoError(t, err)
+		assert.JSONEq(t, body, string(raw))
+	}
+
+	var extra struct {
+		NewField struct {
+			Enabled bool `json:"enabled"`
+		} `json:"new_field"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &extra))
+	assert.True(t, extra.NewField.Enabled)
+}
@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -40,43 +41,46 @@ type RecipientService struct {
 
 // List lists all recipients
 func (s *RecipientService) List() ([]Recipient, *http.Response, error) {
+	return s.ListCtx(context.Background())
+}
+
+// ListCtx is like List, with a context
+func (s *RecipientService) ListCtx(ctx context.Context) ([]Recipient, *http.Response, error) {
 	path := "recipients"
 	if s.client.SkipCache {
 		path = fmt.Sprintf("%s?_=%d", path, time.Now().UnixNano())
 	}
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequestCtx(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var res []Recipient
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return res, resp, err
+	return do[[]Recipient](s.client, req)
 }
 
 // Add creates a new recipient
 func (s *RecipientService) Add(data RecipientItem) (Recipient, *http.Response, error) {
-	req, err := s.client.NewRequest("POST", "recipients", data)
-	if err != nil {
-		return Recipient{}, nil, err
-	}
+	return s.AddCtx(context.Background(), data)
+}
 
-	var res Recipient
-	resp, err := s.client.Do(req, &res)
+// AddCtx is like Add, with a context
+func (s *RecipientService) AddCtx(ctx context.Context, data RecipientItem) (Recipient, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "POST", "recipients", data)
 	if err != nil {
-		return Recipient{}, resp, err
+		return Recipient{}, nil, err
 	}
 
-	return res, resp, err
+	return do[Recipient](s.client, req)
 }
 
 // Remove deletes a recipient by ID
 func (s *RecipientService) Remove(id string) (bool, *http.Response, error) {
-	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("recipients/%s", id), nil)
+	return s.RemoveCtx(context.Background(), id)
+}
+
+// RemoveCtx is like Remove, with a context
+func (s *RecipientService) RemoveCtx(ctx context.Context, id string) (bool, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "DELETE", fmt.Sprintf("recipients/%s", id), nil)
 	if err != nil {
 		return false, nil, err
 	}
@@ -91,3 +95,64 @@ func (s *RecipientService) Remove(id string) (bool, *http.Response, error) {
 
 	return res.Deleted, resp, err
 }
+
+// RecipientUsage tells which checks notify a given recipient
+type RecipientUsage struct {
+	Recipient Recipient
+	// Tokens of the checks notifying the recipient
+	Checks []string
+	// The recipient is notified by every check of the account
+	Everywhere bool
+}
+
+// Unused tells if the recipient is not attached to any check
+func (u RecipientUsage) Unused() bool {
+	return len(u.Checks) == 0
+}
+
+// Usage reports, for every recipient, the checks it is attached to. It helps spotting
+// recipients attached to no check at all, or receiving every alert of the account
+func (s *RecipientService) Usage() ([]RecipientUsage, *http.Response, error) {
+	return s.UsageCtx(context.Background())
+}
+
+// UsageCtx is like Usage, with a context
+func (s *RecipientService) UsageCtx(ctx context.Context) ([]RecipientUsage, *http.Response, error) {
+	recipients, resp, err := s.ListCtx(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	checks, resp, err := s.client.Check.ListCtx(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return recipientUsage(recipients, checks), resp, nil
+}
+
+func recipientUsage(recipients []Recipient, checks []Check) []RecipientUsage {
+	byID := make(map[string][]string, len(recipients))
+	for _, check := range checks {
+		// A recipient listed twice on a check is only counted once
+		seen := make(map[string]bool, len(check.RecipientIDs))
+		for _, id := range check.RecipientIDs {
+			if !seen[id] {
+				seen[id] = true
+				byID[id] = append(byID[id], check.Token)
+			}
+		}
+	}
+
+	res := make([]RecipientUsage, 0, len(recipients))
+	for _, recipient := range recipients {
+		tokens := byID[recipient.ID]
+		res = append(res, RecipientUsage{
+			Recipient:  recipient,
+			Checks:     tokens,
+			Everywhere: len(checks) > 0 && len(tokens) == len(checks),
+		})
+	}
+
+	return res
+}
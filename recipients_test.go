@@ -0,0 +1,40 @@
+package updown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecipientUsage(t *testing.T) {
+	recipients := []Recipient{{ID: "email:1"}, {ID: "slack:2"}, {ID: "sms:3"}}
+	checks := []Check{
+		{Token: "abcd", RecipientIDs: []string{"email:1", "slack:2"}},
+		{Token: "efgh", RecipientIDs: []string{"email:1"}},
+	}
+
+	usage := recipientUsage(recipients, checks)
+	assert.Len(t, usage, 3)
+
+	assert.Equal(t, []string{"abcd", "efgh"}, usage[0].Checks)
+	assert.True(t, usage[0].Everywhere)
+	assert.False(t, usage[0].Unused())
+
+	assert.Equal(t, []string{"abcd"}, usage[1].Checks)
+	assert.False(t, usage[1].Everywhere)
+
+	assert.True(t, usage[2].Unused())
+	assert.False(t, usage[2].Everywhere)
+}
+
+func TestRecipientUsageDuplicates(t *testing.T) {
+	recipients := []Recipient{{ID: "email:1"}}
+	checks := []Check{
+		{Token: "abcd", RecipientIDs: []string{"email:1", "email:1"}},
+		{Token: "efgh"},
+	}
+
+	usage := recipientUsage(recipients, checks)
+	assert.Equal(t, []string{"abcd"}, usage[0].Checks)
+	assert.False(t, usage[0].Everywhere)
+}
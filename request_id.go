@@ -0,0 +1,43 @@
+package updown
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the ID of each request, to correlate failures
+// with logs, traces or support tickets
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID sends requests made with the returned context with the given ID,
+// e.g. the ID of the incoming request being served, instead of a generated one
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// setRequestID sets the ID of a request, unless a header already gives it
+func setRequestID(req *http.Request) {
+	if req.Header.Get(RequestIDHeader) != "" {
+		return
+	}
+	id, _ := req.Context().Value(requestIDKey{}).(string)
+	if id == "" {
+		id = rand.Text()
+	}
+	req.Header.Set(RequestIDHeader, id)
+}
+
+// responseRequestID gives the ID of the request of a response, as echoed by the server
+// or else as sent
+func responseRequestID(r *http.Response) string {
+	if id := r.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	if r.Request != nil {
+		return r.Request.Header.Get(RequestIDHeader)
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package updown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	// Generated for each call
+	_, _, err := client.Check.Get("abcd")
+	require.NoError(t, err)
+	_, _, err = client.Check.Get("abcd")
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.NotEmpty(t, ids[0])
+	assert.NotEqual(t, ids[0], ids[1])
+
+	// Given by the caller
+	_, _, err = client.Check.GetCtx(ContextWithRequestID(context.Background(), "trace-123"), "abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "trace-123", ids[2])
+}
+
+func TestRequestIDInErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/checks/echo" {
+			w.Header().Set(RequestIDHeader, "server-side")
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"Not found"}`))
+	}))
+	ctx := ContextWithRequestID(context.Background(), "trace-123")
+
+	var logs bytes.Buffer
+	client := newTestClient(t, server.URL, WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	_, _, err := client.Check.GetCtx(ctx, "abcd")
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, "trace-123", errResp.RequestID)
+	assert.Contains(t, err.Error(), "(request trace-123)")
+	assert.Contains(t, logs.String(), "request_id=trace-123")
+
+	// The ID echoed by the server wins
+	_, _, err = client.Check.GetCtx(ctx, "echo")
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, "server-side", errResp.RequestID)
+
+	server.Close()
+	_, _, err = client.Check.GetCtx(ctx, "abcd")
+	var netErr *NetworkError
+	require.True(t, errors.As(err, &netErr))
+	assert.Equal(t, "trace-123", netErr.RequestID)
+	assert.Contains(t, err.Error(), "(request trace-123)")
+}
@@ -0,0 +1,132 @@
+package updown
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CacheHeader is set to "hit" on responses served by the response cache, see WithResponseCache
+const CacheHeader = "X-Updown-Cache"
+
+// ResponseCache stores the bodies of GET responses for a limited time, see WithResponseCache.
+// It can be shared by several clients, e.g. backed by Redis across replicas
+type ResponseCache interface {
+	// Get gives the value stored for the key, if any and not expired
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores a value for the key, expiring after ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// WithResponseCache serves GET requests from the cache for ttl after a successful response,
+// e.g. when many replicas poll Check.List. Cache errors are treated as misses, so an unavailable
+// cache does not break API calls. Requests are not cached when SkipCache is set
+func WithResponseCache(cache ResponseCache, ttl time.Duration) Option {
+	return func(c *Client) error {
+		c.responseCache = cache
+		c.responseCacheTTL = ttl
+		return nil
+	}
+}
+
+// DefaultMaxResponseCacheEntries is how many responses a MemoryResponseCache keeps by default
+const DefaultMaxResponseCacheEntries = 1024
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryResponseCache is a response cache working in memory. Beyond MaxEntries, the least
+// recently used responses are dropped, expired or not, as requests with changing parameters,
+// e.g. for metrics, would otherwise fill it up
+type MemoryResponseCache struct {
+	// Maximum number of responses, DefaultMaxResponseCacheEntries when zero
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries lru[memoryCacheEntry]
+}
+
+// NewMemoryResponseCache creates a new memory response cache keeping up to
+// DefaultMaxResponseCacheEntries responses
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{MaxEntries: DefaultMaxResponseCacheEntries}
+}
+
+// Get gives the value stored for the key, if any and not expired
+func (c *MemoryResponseCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, has := c.entries.get(key)
+	if has && !time.Now().Before(entry.expiresAt) {
+		c.entries.remove(key)
+		return nil, false, nil
+	}
+	return entry.value, has, nil
+}
+
+// Set stores a value for the key, expiring after ttl
+func (c *MemoryResponseCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	limit := c.MaxEntries
+	if limit <= 0 {
+		limit = DefaultMaxResponseCacheEntries
+	}
+	c.mu.Lock()
+	c.entries.put(key, memoryCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}, limit)
+	c.mu.Unlock()
+	return nil
+}
+
+// responseCacheKey identifies a request of an account, without revealing its API key
+func (c *Client) responseCacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(c.APIKey + " " + staleKey(req)))
+	return "updown:" + hex.EncodeToString(sum[:])
+}
+
+// cachesResponse tells if the response cache applies to a request
+func (c *Client) cachesResponse(req *http.Request) bool {
+	return c.responseCache != nil && c.responseCacheTTL > 0 && req.Method == "GET" && !c.SkipCache && !isLive(req)
+}
+
+// cachedResponse builds a response from the cache, if it holds one for the request
+func (c *Client) cachedResponse(req *http.Request) (*http.Response, bool) {
+	body, found, err := c.responseCache.Get(req.Context(), c.responseCacheKey(req))
+	if err != nil || !found {
+		return nil, false
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", mediaType)
+	header.Set(CacheHeader, "hit")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, true
+}
+
+// cacheResponse stores the body of a successful response, which is replaced by an in-memory copy
+func (c *Client) cacheResponse(resp *http.Response) error {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	// Failing to fill the cache does not fail the request
+	c.responseCache.Set(resp.Request.Context(), c.responseCacheKey(resp.Request), data, c.responseCacheTTL)
+	return nil
+}
@@ -0,0 +1,91 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryResponseCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryResponseCache()
+
+	_, found, err := c.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, c.Set(ctx, "foo", []byte("bar"), time.Hour))
+	value, found, _ := c.Get(ctx, "foo")
+	assert.True(t, found)
+	assert.Equal(t, []byte("bar"), value)
+
+	require.NoError(t, c.Set(ctx, "foo", []byte("bar"), -time.Second))
+	_, found, _ = c.Get(ctx, "foo")
+	assert.False(t, found)
+
+	// The least recently used entries are dropped beyond the limit
+	c.MaxEntries = 2
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, c.Set(ctx, key, []byte(key), time.Hour))
+	}
+	_, found, _ = c.Get(ctx, "a")
+	assert.False(t, found)
+	_, found, _ = c.Get(ctx, "c")
+	assert.True(t, found)
+	assert.Equal(t, 2, c.entries.order.Len())
+}
+
+type failingResponseCache struct{}
+
+func (failingResponseCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache unavailable")
+}
+
+func (failingResponseCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("cache unavailable")
+}
+
+func TestWithResponseCache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`[{"token":"abcd"}]`))
+	}))
+	defer server.Close()
+
+	// Replicas share the cache
+	cache := NewMemoryResponseCache()
+	replicas := []*Client{
+		newTestClient(t, server.URL, WithResponseCache(cache, time.Minute)),
+		newTestClient(t, server.URL, WithResponseCache(cache, time.Minute)),
+	}
+	for _, client := range replicas {
+		checks, _, err := client.Check.List()
+		require.NoError(t, err)
+		assert.Equal(t, []Check{{Token: "abcd"}}, checks)
+	}
+	assert.Equal(t, 1, calls)
+
+	_, resp, err := replicas[0].Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "hit", resp.Header.Get(CacheHeader))
+
+	// Other accounts do not share responses
+	other, err := NewClient("other-key", WithBaseURL(server.URL), WithResponseCache(cache, time.Minute))
+	require.NoError(t, err)
+	_, _, err = other.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Unavailable caches do not fail requests
+	client := newTestClient(t, server.URL, WithResponseCache(failingResponseCache{}, time.Minute))
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,239 @@
+package updown
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy describes how requests failing with a network error or a 5xx status are retried
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. Retries are disabled below 2
+	MaxAttempts int
+	// Delay before the first retry, doubled after each attempt, with jitter
+	InitialBackoff time.Duration
+	// Maximum delay between two attempts
+	MaxBackoff time.Duration
+	// Retry non-idempotent operations too, such as creating a check, which may then be
+	// created twice. Only operations which can safely be repeated, such as updating or
+	// deleting a check, are retried otherwise. See ContextWithIdempotent to decide per call
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy makes up to 3 attempts, waiting about 0.5s then 1s between them
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// WithRetryPolicy retries failed requests as described by the policy. Requests are not retried by default
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		c.retry = policy
+		return nil
+	}
+}
+
+// attempts gives the number of times a request may be attempted, depending on whether it
+// is safe to retry
+func (p RetryPolicy) attempts(req *http.Request, retryable bool) int {
+	if p.MaxAttempts < 2 || !retryable || !rewindable(req) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff gives the delay before the given retry, starting at 1: half of the exponential
+// delay, plus a random part up to the other half
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// isIdempotent tells if requests with the given method are idempotent by definition
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isTransient tells if a failed attempt is worth retrying
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return (&NetworkError{Err: err}).Temporary()
+	}
+	return resp.StatusCode >= 500
+}
+
+// Bounds of the waits between attempts of a rate limited request
+const (
+	// Minimum wait, even when the API asks to retry right away
+	minRateLimitWait = time.Second
+	// Maximum number of retries, whatever the budget
+	maxRateLimitRetries = 10
+)
+
+// WithRateLimitRetry waits and retries requests rejected with a 429 status, for the
+// duration given by the Retry-After header, and at least a second. Requests are retried
+// until the total time spent waiting would exceed the budget, or up to 10 times, then the
+// 429 response is returned. Such requests were not processed by the API, so they are
+// retried whatever their method
+func WithRateLimitRetry(budget time.Duration) Option {
+	return func(c *Client) error {
+		c.rateLimitBudget = budget
+		return nil
+	}
+}
+
+// retryAfter gives how long to wait before retrying a rate limited request
+func retryAfter(resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(max(0, seconds)) * time.Second
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return until(at)
+		}
+	}
+	if rl, ok := ParseRateLimit(resp); ok && !rl.Reset.IsZero() {
+		return until(rl.Reset)
+	}
+	return time.Second
+}
+
+// until gives the time left until t, zero when past
+func until(t time.Time) time.Duration {
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// RetryInfo describes the attempts made for a request, telling a request which failed
+// right away from one which failed after several retries
+type RetryInfo struct {
+	// Number of attempts, including the first one
+	Attempts int
+	// Time spent from the first attempt to the end of the last one, waits included
+	Elapsed time.Duration
+	// Status code of each attempt, 0 for attempts which failed with a network error
+	StatusCodes []int
+}
+
+// Retries gives the number of retries, not counting the first attempt
+func (r RetryInfo) Retries() int {
+	return max(0, r.Attempts-1)
+}
+
+// String describes the attempts, e.g. "3 attempts over 1.5s (503, 503, 0)"
+func (r RetryInfo) String() string {
+	codes := make([]string, len(r.StatusCodes))
+	for i, code := range r.StatusCodes {
+		codes[i] = strconv.Itoa(code)
+	}
+	return fmt.Sprintf("%d attempts over %s (%s)", r.Attempts, r.Elapsed.Round(time.Millisecond), strings.Join(codes, ", "))
+}
+
+// send performs a request, retrying it according to the retry policy of the client, and describes the attempts made
+func (c *Client) send(req *http.Request) (resp *http.Response, info RetryInfo, err error) {
+	attempts := c.retry.attempts(req, c.retryable(req))
+	var waited time.Duration
+	limited := 0
+	start := time.Now()
+	defer func() { info.Elapsed = time.Since(start) }()
+	for attempt := 1; ; {
+		resp, err = c.attempt(req)
+		info.Attempts++
+		if resp != nil {
+			info.StatusCodes = append(info.StatusCodes, resp.StatusCode)
+		} else {
+			info.StatusCodes = append(info.StatusCodes, 0)
+		}
+		if req.Context().Err() != nil {
+			return resp, info, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err == nil && resp.StatusCode == http.StatusTooManyRequests:
+			delay = retryAfter(resp)
+			if delay < minRateLimitWait {
+				delay = minRateLimitWait
+			}
+			if c.rateLimitBudget <= 0 || waited+delay > c.rateLimitBudget || limited >= maxRateLimitRetries || !rewindable(req) {
+				return resp, info, err
+			}
+			waited += delay
+			limited++
+		case attempt < attempts && isTransient(resp, err):
+			delay = c.retry.backoff(attempt)
+			attempt++
+		default:
+			return resp, info, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, info, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, info, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// attempt sends a request once within its timeout, dumping it when debugging
+func (c *Client) attempt(req *http.Request) (*http.Response, error) {
+	req, cancel := c.withTimeout(req)
+	if c.debug != nil {
+		c.debug.dumpRequest(req, c.APIKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		cancel()
+		if c.debug != nil {
+			c.debug.write("response", nil, err, c.APIKey)
+		}
+		return resp, err
+	}
+	if c.debug != nil {
+		c.debug.dumpResponse(resp, c.APIKey)
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// rewindable tells if the body of a request can be sent again
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
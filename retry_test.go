@@ -0,0 +1,185 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer fails the given number of requests with a 503 status, then succeeds
+func flakyServer(t *testing.T, failures int) (*httptest.Server, *int) {
+	calls := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+var fastRetries = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryIdempotent(t *testing.T) {
+	server, calls := flakyServer(t, 2)
+	client := newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+
+	check, resp, err := client.Check.Get("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "abcd", check.Token)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := flakyServer(t, 5)
+	client := newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+
+	_, resp, err := client.Check.Get("abcd")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetryInfo(t *testing.T) {
+	server, _ := flakyServer(t, 5)
+	client := newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+
+	// Errors tell the attempts made before giving up
+	_, _, err := client.Check.Get("abcd")
+	var errResp *ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, 3, errResp.Attempts.Attempts)
+	assert.Equal(t, 2, errResp.Attempts.Retries())
+	assert.Equal(t, []int{503, 503, 503}, errResp.Attempts.StatusCodes)
+	assert.Positive(t, errResp.Attempts.Elapsed)
+	assert.Contains(t, err.Error(), ", after 3 attempts over ")
+
+	// Requests failing right away are told apart
+	client = newTestClient(t, server.URL)
+	_, _, err = client.Check.Get("abcd")
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, 1, errResp.Attempts.Attempts)
+	assert.NotContains(t, err.Error(), "attempts")
+
+	// Network errors too
+	server.Close()
+	client = newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+	_, _, err = client.Check.Get("abcd")
+	var netErr *NetworkError
+	require.ErrorAs(t, err, &netErr)
+	assert.Equal(t, []int{0, 0, 0}, netErr.Attempts.StatusCodes)
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	server, calls := flakyServer(t, 1)
+	client := newTestClient(t, server.URL, WithRetryPolicy(fastRetries))
+
+	// POST requests are not retried by default
+	_, _, err := client.Check.Add(CheckItem{URL: "https://example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls)
+
+	server, calls = flakyServer(t, 1)
+	policy := fastRetries
+	policy.RetryNonIdempotent = true
+	client = newTestClient(t, server.URL, WithRetryPolicy(policy))
+
+	check, _, err := client.Check.Add(CheckItem{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for retry, limit := range map[int]time.Duration{1: 100, 2: 200, 3: 400, 4: 800, 5: 1000, 50: 1000} {
+		limit *= time.Millisecond
+		d := policy.backoff(retry)
+		assert.GreaterOrEqual(t, d, limit/2, "retry %d", retry)
+		assert.LessOrEqual(t, d, limit, "retry %d", retry)
+	}
+}
+
+func TestRateLimitRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+
+	// Rate limited requests are retried whatever their method
+	client := newTestClient(t, server.URL, WithRateLimitRetry(2*time.Second))
+	check, _, err := client.Check.Add(CheckItem{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+	assert.Equal(t, 2, calls)
+
+	// Without budget, the 429 response is returned
+	calls = 0
+	client = newTestClient(t, server.URL)
+	_, resp, err := client.Check.Get("abcd")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestRateLimitRetryBudget(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// Waiting would exceed the budget
+	client := newTestClient(t, server.URL, WithRateLimitRetry(5*time.Second))
+	_, resp, err := client.Check.Get("abcd")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimitRetryImmediate(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// Retrying right away is not allowed to flood the API: waits last at least a second
+	client := newTestClient(t, server.URL, WithRateLimitRetry(1500*time.Millisecond))
+	start := time.Now()
+	_, resp, err := client.Check.Get("abcd")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, time.Second, retryAfter(resp))
+
+	resp.Header.Set("Retry-After", "7")
+	assert.Equal(t, 7*time.Second, retryAfter(resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Minute), float64(retryAfter(resp)), float64(2*time.Second))
+}
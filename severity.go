@@ -0,0 +1,63 @@
+package updown
+
+import (
+	"time"
+)
+
+// DowntimeSeverity labels a downtime according to its length
+type DowntimeSeverity string
+
+const (
+	SeverityBlip      DowntimeSeverity = "blip"
+	SeverityMinor     DowntimeSeverity = "minor"
+	SeverityMajor     DowntimeSeverity = "major"
+	SeverityProlonged DowntimeSeverity = "prolonged"
+)
+
+// SeverityThresholds are the maximum durations of blips, minor and major downtimes.
+// Longer downtimes are prolonged
+type SeverityThresholds struct {
+	Blip  time.Duration
+	Minor time.Duration
+	Major time.Duration
+}
+
+// DefaultSeverityThresholds classifies downtimes under 2 minutes as blips, under 15 minutes
+// as minor and under an hour as major
+var DefaultSeverityThresholds = SeverityThresholds{
+	Blip:  2 * time.Minute,
+	Minor: 15 * time.Minute,
+	Major: time.Hour,
+}
+
+// Classify gives the severity of a downtime. Ongoing downtimes are classified by
+// their length so far
+func (t SeverityThresholds) Classify(d Downtime) DowntimeSeverity {
+	length := d.Length()
+	switch {
+	case length < t.Blip:
+		return SeverityBlip
+	case length < t.Minor:
+		return SeverityMinor
+	case length < t.Major:
+		return SeverityMajor
+	default:
+		return SeverityProlonged
+	}
+}
+
+// Length gives how long the downtime lasted, or has lasted so far when ongoing
+func (d Downtime) Length() time.Duration {
+	if d.EndedAt != "" || d.Duration > 0 {
+		return time.Duration(d.Duration) * time.Second
+	}
+	if from, err := time.Parse(time.RFC3339, d.StartedAt); err == nil {
+		return time.Since(from)
+	}
+	return 0
+}
+
+// Severity gives the severity of the downtime with the default thresholds
+func (d Downtime) Severity() DowntimeSeverity {
+	return DefaultSeverityThresholds.Classify(d)
+}
@@ -0,0 +1,22 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDowntimeSeverity(t *testing.T) {
+	assert.Equal(t, SeverityBlip, Downtime{EndedAt: "2024-04-01T00:01:00Z", Duration: 60}.Severity())
+	assert.Equal(t, SeverityMinor, Downtime{EndedAt: "2024-04-01T00:05:00Z", Duration: 300}.Severity())
+	assert.Equal(t, SeverityMajor, Downtime{EndedAt: "2024-04-01T00:30:00Z", Duration: 1800}.Severity())
+	assert.Equal(t, SeverityProlonged, Downtime{EndedAt: "2024-04-01T03:00:00Z", Duration: 10800}.Severity())
+
+	// Ongoing downtimes are classified by their length so far
+	ongoing := Downtime{StartedAt: time.Now().Add(-20 * time.Minute).Format(time.RFC3339)}
+	assert.Equal(t, SeverityMajor, ongoing.Severity())
+
+	strict := SeverityThresholds{Blip: 30 * time.Second, Minor: time.Minute, Major: 5 * time.Minute}
+	assert.Equal(t, SeverityProlonged, strict.Classify(ongoing))
+}
@@ -0,0 +1,102 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrNoShard indicates that no client is configured for the alias of a check
+var ErrNoShard = errors.New("No client is configured for the given alias")
+
+// Shards routes check operations to the client of the account owning the check, based on the
+// alias prefix, for large accounts split across several API keys
+type Shards struct {
+	// Clients by alias prefix, the longest matching prefix wins
+	Prefixes map[string]*Client
+	// Client for aliases matching no prefix. Such aliases are refused when nil
+	Default *Client
+}
+
+// For gives the client of the account owning checks with the given alias
+func (s Shards) For(alias string) (*Client, error) {
+	client, longest := s.Default, -1
+	for prefix, c := range s.Prefixes {
+		if strings.HasPrefix(alias, prefix) && len(prefix) > longest {
+			client, longest = c, len(prefix)
+		}
+	}
+	if client == nil {
+		return nil, ErrNoShard
+	}
+	return client, nil
+}
+
+// clients lists every distinct client
+func (s Shards) clients() []*Client {
+	var res []*Client
+	seen := make(map[*Client]bool)
+	for _, c := range append([]*Client{s.Default}, s.mapped()...) {
+		if c != nil && !seen[c] {
+			seen[c] = true
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (s Shards) mapped() []*Client {
+	res := make([]*Client, 0, len(s.Prefixes))
+	for _, c := range s.Prefixes {
+		res = append(res, c)
+	}
+	return res
+}
+
+// Add adds a check to the account owning its alias
+func (s Shards) Add(data CheckItem) (Check, *http.Response, error) {
+	return s.AddCtx(context.Background(), data)
+}
+
+// AddCtx is like Add, with a context
+func (s Shards) AddCtx(ctx context.Context, data CheckItem) (Check, *http.Response, error) {
+	client, err := s.For(data.Alias)
+	if err != nil {
+		return Check{}, nil, err
+	}
+	return client.Check.AddCtx(ctx, data)
+}
+
+// TokenForAlias finds the token of a check in the account owning its alias, along with the client of this account
+func (s Shards) TokenForAlias(alias string) (*Client, string, error) {
+	return s.TokenForAliasCtx(context.Background(), alias)
+}
+
+// TokenForAliasCtx is like TokenForAlias, with a context
+func (s Shards) TokenForAliasCtx(ctx context.Context, alias string) (*Client, string, error) {
+	client, err := s.For(alias)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := client.Check.TokenForAliasCtx(ctx, alias)
+	return client, token, err
+}
+
+// List lists the checks of every account
+func (s Shards) List() ([]Check, error) {
+	return s.ListCtx(context.Background())
+}
+
+// ListCtx is like List, with a context
+func (s Shards) ListCtx(ctx context.Context) ([]Check, error) {
+	var res []Check
+	for _, client := range s.clients() {
+		checks, _, err := client.Check.ListCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, checks...)
+	}
+	return res, nil
+}
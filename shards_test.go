@@ -0,0 +1,48 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardsFor(t *testing.T) {
+	eu, euBilling, fallback := &Client{}, &Client{}, &Client{}
+	shards := Shards{Prefixes: map[string]*Client{"eu-": eu, "eu-billing-": euBilling}}
+
+	c, err := shards.For("eu-search")
+	require.NoError(t, err)
+	assert.Same(t, eu, c)
+
+	c, err = shards.For("eu-billing-api")
+	require.NoError(t, err)
+	assert.Same(t, euBilling, c)
+
+	_, err = shards.For("us-search")
+	assert.Equal(t, ErrNoShard, err)
+
+	shards.Default = fallback
+	c, err = shards.For("us-search")
+	require.NoError(t, err)
+	assert.Same(t, fallback, c)
+}
+
+func TestShardsList(t *testing.T) {
+	account := func(body string) *Client {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		return newTestClient(t, server.URL)
+	}
+	eu, us := account(`[{"token":"abcd"}]`), account(`[{"token":"efgh"}]`)
+
+	// Clients mapped to several prefixes are only listed once
+	shards := Shards{Prefixes: map[string]*Client{"eu-": eu, "fr-": eu}, Default: us}
+	checks, err := shards.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Check{{Token: "abcd"}, {Token: "efgh"}}, checks)
+}
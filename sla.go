@@ -0,0 +1,142 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// SLACreditTier grants a credit, as a percentage of the bill, when uptime falls below a threshold
+type SLACreditTier struct {
+	// Uptime percentage under which the credit applies, e.g. 99.9
+	Below float64
+	// Credit percentage, e.g. 10
+	Credit float64
+}
+
+// SLACreditTable lists the credit tiers of an SLA, e.g. <99.9% → 10%, <99% → 25%
+type SLACreditTable []SLACreditTier
+
+// Credit gives the credit percentage owed for the given uptime percentage
+func (t SLACreditTable) Credit(uptime float64) float64 {
+	credit := 0.0
+	for _, tier := range t {
+		if uptime < tier.Below && tier.Credit > credit {
+			credit = tier.Credit
+		}
+	}
+	return credit
+}
+
+// SLACredit is the credit owed for a status page over a month
+type SLACredit struct {
+	StatusPage StatusPage
+	// Uptime percentage of the month, the page being down when any of its checks is down
+	Uptime float64
+	// Credit percentage owed
+	Credit float64
+}
+
+// MonthlyUptime computes the uptime percentage over the calendar month containing the given
+// time, in its location. Overlapping downtimes are only counted once, and ongoing downtimes
+// are counted until now. Only the elapsed part of the current month is considered, and a
+// month which has not started yet is reported as fully up
+func MonthlyUptime(downtimes []Downtime, month time.Time) float64 {
+	start, end := monthBounds(month, time.Now())
+	if !end.After(start) {
+		return 100
+	}
+
+	down := downDuration(downtimes, start, end)
+	return 100 * (1 - float64(down)/float64(end.Sub(start)))
+}
+
+// monthBounds gives the start and the end of the calendar month containing the given time,
+// the end being capped at now
+func monthBounds(month, now time.Time) (time.Time, time.Time) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	end := start.AddDate(0, 1, 0)
+	if end.After(now) {
+		end = now
+	}
+	return start, end
+}
+
+// downDuration gives the time spent down between start and end
+func downDuration(downtimes []Downtime, start, end time.Time) time.Duration {
+	type interval struct{ from, to time.Time }
+
+	var intervals []interval
+	for _, d := range downtimes {
+		from, to, ok := downtimeBounds(d)
+		if !ok {
+			continue
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			intervals = append(intervals, interval{from, to})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].from.Before(intervals[j].from) })
+
+	var total time.Duration
+	var cur interval
+	for i, in := range intervals {
+		switch {
+		case i == 0:
+			cur = in
+		case !in.from.After(cur.to):
+			if in.to.After(cur.to) {
+				cur.to = in.to
+			}
+		default:
+			total += cur.to.Sub(cur.from)
+			cur = in
+		}
+	}
+	if len(intervals) > 0 {
+		total += cur.to.Sub(cur.from)
+	}
+	return total
+}
+
+// SLACredits computes the credit owed for each status page over the calendar month
+// containing the given time
+func (s *StatusPageService) SLACredits(month time.Time, table SLACreditTable) ([]SLACredit, *http.Response, error) {
+	return s.SLACreditsCtx(context.Background(), month, table)
+}
+
+// SLACreditsCtx is like SLACredits, with a context
+func (s *StatusPageService) SLACreditsCtx(ctx context.Context, month time.Time, table SLACreditTable) ([]SLACredit, *http.Response, error) {
+	pages, resp, err := s.ListCtx(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	byCheck := make(map[string][]Downtime)
+	res := make([]SLACredit, 0, len(pages))
+	for _, page := range pages {
+		var downtimes []Downtime
+		for _, token := range page.Checks {
+			if _, ok := byCheck[token]; !ok {
+				byCheck[token], resp, err = s.client.Downtime.ListSinceCtx(ctx, token, start)
+				if err != nil {
+					return nil, resp, err
+				}
+			}
+			downtimes = append(downtimes, byCheck[token]...)
+		}
+
+		uptime := MonthlyUptime(downtimes, month)
+		res = append(res, SLACredit{StatusPage: page, Uptime: uptime, Credit: table.Credit(uptime)})
+	}
+
+	return res, resp, nil
+}
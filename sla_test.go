@@ -0,0 +1,47 @@
+package updown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLACreditTable(t *testing.T) {
+	table := SLACreditTable{{Below: 99.9, Credit: 10}, {Below: 99, Credit: 25}}
+
+	assert.Equal(t, 0.0, table.Credit(99.95))
+	assert.Equal(t, 10.0, table.Credit(99.5))
+	assert.Equal(t, 25.0, table.Credit(98))
+}
+
+func TestMonthlyUptime(t *testing.T) {
+	// April has 30 days, 43200 minutes
+	month := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 100.0, MonthlyUptime(nil, month))
+
+	downtimes := []Downtime{
+		// 432 minutes, 1% of the month
+		{StartedAt: "2024-04-10T00:00:00Z", EndedAt: "2024-04-10T07:12:00Z"},
+		// Overlaps the previous one, only counted once
+		{StartedAt: "2024-04-10T01:00:00Z", EndedAt: "2024-04-10T02:00:00Z"},
+		// Only the part within April counts: 432 minutes
+		{StartedAt: "2024-03-31T23:00:00Z", EndedAt: "2024-04-01T07:12:00Z"},
+		// Outside of the month
+		{StartedAt: "2024-05-02T00:00:00Z", EndedAt: "2024-05-03T00:00:00Z"},
+	}
+	assert.InDelta(t, 98.0, MonthlyUptime(downtimes, month), 0.0001)
+}
+
+func TestMonthlyUptimeCurrentMonth(t *testing.T) {
+	now := time.Now()
+
+	// Only the elapsed part of the month counts, down all along since it started
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	downtimes := []Downtime{{StartedAt: start.Add(-time.Hour).Format(time.RFC3339)}}
+	assert.InDelta(t, 0.0, MonthlyUptime(downtimes, now), 0.01)
+
+	// A month which has not started is fully up
+	assert.Equal(t, 100.0, MonthlyUptime(nil, now.AddDate(0, 2, 0)))
+}
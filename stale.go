@@ -0,0 +1,91 @@
+package updown
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StaleHeader is set on responses served from the last successful response, when
+// Client.ServeStale is enabled and the API could not be reached. It holds the time
+// of the successful response
+const StaleHeader = "X-Updown-Stale-Since"
+
+// DefaultMaxStaleEntries is how many responses are kept for ServeStale by default. Metrics and
+// downtimes are requested with ever changing parameters, so responses are not all kept
+const DefaultMaxStaleEntries = 256
+
+// StaleSince tells if a response was served from stale data, and when this data was fetched
+func StaleSince(resp *http.Response) (time.Time, bool) {
+	if resp == nil {
+		return time.Time{}, false
+	}
+	since, err := time.Parse(time.RFC3339Nano, resp.Header.Get(StaleHeader))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return since, true
+}
+
+type staleEntry struct {
+	body      []byte
+	header    http.Header
+	fetchedAt time.Time
+}
+
+// staleCache keeps the last successful response of the most recently used GET requests
+type staleCache struct {
+	mu      sync.Mutex
+	entries lru[*staleEntry]
+}
+
+// staleKey identifies a request, ignoring the cache-busting parameter
+func staleKey(req *http.Request) string {
+	u := *req.URL
+	q := u.Query()
+	q.Del("_")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// store records the body of a successful response, which is replaced by an in-memory copy,
+// keeping at most limit responses
+func (c *staleCache) store(resp *http.Response, limit int) error {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if limit <= 0 {
+		return nil
+	}
+	entry := &staleEntry{body: data, header: resp.Header.Clone(), fetchedAt: time.Now()}
+	c.mu.Lock()
+	c.entries.put(staleKey(resp.Request), entry, limit)
+	c.mu.Unlock()
+	return nil
+}
+
+// response builds a response from the last successful response to the same request
+func (c *staleCache) response(req *http.Request) (*http.Response, bool) {
+	c.mu.Lock()
+	entry, has := c.entries.get(staleKey(req))
+	c.mu.Unlock()
+	if !has {
+		return nil, false
+	}
+
+	header := entry.header.Clone()
+	header.Set(StaleHeader, entry.fetchedAt.Format(time.RFC3339Nano))
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(entry.body)),
+		Request:    req,
+	}, true
+}
@@ -0,0 +1,81 @@
+package updown
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeStale(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`[{"token":"abcd","url":"https://example.com"}]`))
+	}))
+
+	client := newTestClient(t, server.URL)
+	client.ServeStale = true
+
+	checks, resp, err := client.Check.List()
+	require.NoError(t, err)
+	_, stale := StaleSince(resp)
+	assert.False(t, stale)
+
+	// The API fails
+	failing = true
+	checks, resp, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", checks[0].Token)
+	_, stale = StaleSince(resp)
+	assert.True(t, stale)
+
+	// The API is unreachable
+	server.Close()
+	checks, resp, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", checks[0].Token)
+	_, stale = StaleSince(resp)
+	assert.True(t, stale)
+
+	// Without stale data, errors are returned
+	client.ServeStale = false
+	_, _, err = client.Check.List()
+	assert.Error(t, err)
+}
+
+func TestServeStaleLimit(t *testing.T) {
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"token":"` + strings.TrimPrefix(r.URL.Path, "/checks/") + `"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.ServeStale = true
+	client.MaxStaleEntries = 2
+	for _, token := range []string{"a", "b", "a", "c"} {
+		_, _, err := client.Check.Get(token)
+		require.NoError(t, err)
+	}
+
+	// The least recently used response was dropped
+	failing = true
+	check, _, err := client.Check.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", check.Token)
+	_, _, err = client.Check.Get("c")
+	require.NoError(t, err)
+	_, _, err = client.Check.Get("b")
+	assert.Error(t, err)
+}
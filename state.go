@@ -0,0 +1,122 @@
+package updown
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// StateStore keeps the latest known state of every check, so many consumers can read
+// the current status without extra API calls. It is fed by Refresh or by Set, e.g.
+// from webhooks
+type StateStore struct {
+	mu     sync.RWMutex
+	checks map[string]Check
+	subs   map[chan Check]struct{}
+}
+
+// NewStateStore creates an empty state store
+func NewStateStore() *StateStore {
+	return &StateStore{
+		checks: make(map[string]Check),
+		subs:   make(map[chan Check]struct{}),
+	}
+}
+
+// Get gives the latest known state of a check by its token
+func (s *StateStore) Get(token string) (Check, bool) {
+	s.mu.RLock()
+	check, has := s.checks[token]
+	s.mu.RUnlock()
+	return check, has
+}
+
+// All gives the latest known state of every check, sorted by token
+func (s *StateStore) All() []Check {
+	s.mu.RLock()
+	res := make([]Check, 0, len(s.checks))
+	for _, check := range s.checks {
+		res = append(res, check)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Token < res[j].Token })
+	return res
+}
+
+// Set records the state of a check, notifying subscribers when it changed
+func (s *StateStore) Set(check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, has := s.checks[check.Token]; has && reflect.DeepEqual(prev, check) {
+		return
+	}
+	s.checks[check.Token] = check
+	for sub := range s.subs {
+		// Slow subscribers miss updates rather than blocking the store
+		select {
+		case sub <- check:
+		default:
+		}
+	}
+}
+
+// Remove forgets about a check
+func (s *StateStore) Remove(token string) {
+	s.mu.Lock()
+	delete(s.checks, token)
+	s.mu.Unlock()
+}
+
+// Refresh lists all the checks and records their state. Checks which no longer
+// exist are forgotten
+func (s *StateStore) Refresh(service *CheckService) (*http.Response, error) {
+	return s.RefreshCtx(context.Background(), service)
+}
+
+// RefreshCtx is like Refresh, with a context
+func (s *StateStore) RefreshCtx(ctx context.Context, service *CheckService) (*http.Response, error) {
+	checks, resp, err := service.ListCtx(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	seen := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		seen[check.Token] = true
+		s.Set(check)
+	}
+
+	s.mu.Lock()
+	for token := range s.checks {
+		if !seen[token] {
+			delete(s.checks, token)
+		}
+	}
+	s.mu.Unlock()
+
+	return resp, nil
+}
+
+// Subscribe receives the checks whose state changed. Updates are dropped when the
+// buffer of the channel is full. The returned function ends the subscription and
+// closes the channel
+func (s *StateStore) Subscribe(buffer int) (<-chan Check, func()) {
+	sub := make(chan Check, buffer)
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return sub, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subs, sub)
+			close(sub)
+			s.mu.Unlock()
+		})
+	}
+}
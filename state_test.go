@@ -0,0 +1,35 @@
+package updown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateStore(t *testing.T) {
+	s := NewStateStore()
+	updates, cancel := s.Subscribe(10)
+
+	s.Set(Check{Token: "efgh", Down: false})
+	s.Set(Check{Token: "abcd", Down: false})
+	// Unchanged state is not notified
+	s.Set(Check{Token: "abcd", Down: false})
+	s.Set(Check{Token: "abcd", Down: true})
+
+	check, has := s.Get("abcd")
+	assert.True(t, has)
+	assert.True(t, check.Down)
+	assert.Equal(t, []string{"abcd", "efgh"}, []string{s.All()[0].Token, s.All()[1].Token})
+
+	cancel()
+	cancel()
+	var received []Check
+	for c := range updates {
+		received = append(received, c)
+	}
+	assert.Equal(t, []Check{{Token: "efgh"}, {Token: "abcd"}, {Token: "abcd", Down: true}}, received)
+
+	s.Remove("abcd")
+	_, has = s.Get("abcd")
+	assert.False(t, has)
+}
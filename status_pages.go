@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -42,29 +43,33 @@ type removeStatusPageResponse struct {
 
 // List lists all status pages
 func (s *StatusPageService) List() ([]StatusPage, *http.Response, error) {
+	return s.ListCtx(context.Background())
+}
+
+// ListCtx is like List, with a context
+func (s *StatusPageService) ListCtx(ctx context.Context) ([]StatusPage, *http.Response, error) {
 	path := "status_pages"
 	if s.client.SkipCache {
 		path = fmt.Sprintf("%s?_=%d", path, time.Now().UnixNano())
 	}
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequestCtx(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var res []StatusPage
-	resp, err := s.client.Do(req, &res)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return res, resp, err
+	return do[[]StatusPage](s.client, req)
 }
 
 // Get gets a single status page by its token from the list
 func (s *StatusPageService) Get(token string) (StatusPage, *http.Response, error) {
+	return s.GetCtx(context.Background(), token)
+}
+
+// GetCtx is like Get, with a context
+func (s *StatusPageService) GetCtx(ctx context.Context, token string) (StatusPage, *http.Response, error) {
 	// The API doesn't have a GET /status_pages/:token endpoint
 	// We need to list all and find the matching one
-	pages, resp, err := s.List()
+	pages, resp, err := s.ListCtx(ctx)
 	if err != nil {
 		return StatusPage{}, resp, err
 	}
@@ -75,44 +80,47 @@ func (s *StatusPageService) Get(token string) (StatusPage, *http.Response, error
 		}
 	}
 
-	return StatusPage{}, resp, fmt.Errorf("status page with token %s not found", token)
+	return StatusPage{}, resp, fmt.Errorf("status page with token %s: %w", token, ErrNotFound)
 }
 
 // Add creates a new status page
 func (s *StatusPageService) Add(data StatusPageItem) (StatusPage, *http.Response, error) {
-	req, err := s.client.NewRequest("POST", "status_pages", data)
-	if err != nil {
-		return StatusPage{}, nil, err
-	}
+	return s.AddCtx(context.Background(), data)
+}
 
-	var res StatusPage
-	resp, err := s.client.Do(req, &res)
+// AddCtx is like Add, with a context
+func (s *StatusPageService) AddCtx(ctx context.Context, data StatusPageItem) (StatusPage, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "POST", "status_pages", data)
 	if err != nil {
-		return StatusPage{}, resp, err
+		return StatusPage{}, nil, err
 	}
 
-	return res, resp, err
+	return do[StatusPage](s.client, req)
 }
 
 // Update updates a status page
 func (s *StatusPageService) Update(token string, data StatusPageItem) (StatusPage, *http.Response, error) {
-	req, err := s.client.NewRequest("PUT", pathForStatusPageToken(token), data)
-	if err != nil {
-		return StatusPage{}, nil, err
-	}
+	return s.UpdateCtx(context.Background(), token, data)
+}
 
-	var res StatusPage
-	resp, err := s.client.Do(req, &res)
+// UpdateCtx is like Update, with a context
+func (s *StatusPageService) UpdateCtx(ctx context.Context, token string, data StatusPageItem) (StatusPage, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "PUT", pathForStatusPageToken(token), data)
 	if err != nil {
-		return StatusPage{}, resp, err
+		return StatusPage{}, nil, err
 	}
 
-	return res, resp, err
+	return do[StatusPage](s.client, req)
 }
 
 // Remove removes a status page by its token
 func (s *StatusPageService) Remove(token string) (bool, *http.Response, error) {
-	req, err := s.client.NewRequest("DELETE", pathForStatusPageToken(token), nil)
+	return s.RemoveCtx(context.Background(), token)
+}
+
+// RemoveCtx is like Remove, with a context
+func (s *StatusPageService) RemoveCtx(ctx context.Context, token string) (bool, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "DELETE", pathForStatusPageToken(token), nil)
 	if err != nil {
 		return false, nil, err
 	}
@@ -0,0 +1,60 @@
+package updown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// streamDecoder decodes a response body as it is read, see decodeResponse
+type streamDecoder interface {
+	decodeFrom(r io.Reader) error
+}
+
+// arrayStream decodes a JSON array one element at a time, passing each one to fn
+type arrayStream[T any] func(T) error
+
+func (fn arrayStream[T]) decodeFrom(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("updown: decoding response: %w", err)
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("updown: decoding response: expected an array, got %v", tok)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("updown: decoding response: %w", err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("updown: decoding response: %w", err)
+	}
+	return nil
+}
+
+// ListEach lists all the checks, passing them to fn one at a time as the response is decoded,
+// instead of holding them all in memory. It stops at the first error returned by fn, and returns it
+func (s *CheckService) ListEach(fn func(Check) error) (*http.Response, error) {
+	return s.ListEachCtx(context.Background(), fn)
+}
+
+// ListEachCtx is like ListEach, with a context
+func (s *CheckService) ListEachCtx(ctx context.Context, fn func(Check) error) (*http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "GET", "checks", nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(req, arrayStream[Check](fn))
+}
@@ -0,0 +1,49 @@
+package updown
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEach(t *testing.T) {
+	body := `[{"token":"a"},{"token":"b"},{"token":"c"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	var tokens []string
+	_, err := client.Check.ListEach(func(c Check) error {
+		tokens = append(tokens, c.Token)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, tokens)
+
+	// Stopping early
+	errStop := errors.New("stop")
+	tokens = nil
+	_, err = client.Check.ListEach(func(c Check) error {
+		tokens = append(tokens, c.Token)
+		if c.Token == "b" {
+			return errStop
+		}
+		return nil
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, []string{"a", "b"}, tokens)
+
+	body = `{"error":"nope"}`
+	_, err = client.Check.ListEach(func(c Check) error { return nil })
+	assert.Error(t, err)
+
+	body = `[{"token":"a"},{"token":`
+	_, err = client.Check.ListEach(func(c Check) error { return nil })
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package updown
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout limits the time taken by each request unless set otherwise with WithTimeout,
+// so calls cannot hang forever when the API stalls
+const DefaultTimeout = time.Minute
+
+type timeoutKey struct{}
+
+// ContextWithRequestTimeout overrides the timeout of the client for the requests made with the
+// returned context. Zero disables the timeout
+func ContextWithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+// requestTimeout gives the timeout of a request
+func (c *Client) requestTimeout(req *http.Request) time.Duration {
+	if timeout, ok := req.Context().Value(timeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return c.timeout
+}
+
+// withTimeout bounds the context of a request by the timeout, until its response body is closed
+func (c *Client) withTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	timeout := c.requestTimeout(req)
+	if timeout <= 0 {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	return req.WithContext(ctx), cancel
+}
+
+// cancelBody cancels the context of a request once its response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,42 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/checks/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+		}
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL, WithTimeout(50*time.Millisecond))
+
+	_, _, err := client.Check.Get("slow")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// Responses are read before the timeout expires
+	check, _, err := client.Check.Get("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+
+	// The timeout can be overridden for some calls
+	ctx := ContextWithRequestTimeout(context.Background(), 0)
+	check, _, err = client.Check.GetCtx(ctx, "slow")
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", check.Token)
+}
@@ -0,0 +1,233 @@
+package updown
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClientAddressFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	resp, err := NewHTTPClient(TransportConfig{AddressFamily: IPv4Only}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// The test server only listens on IPv4
+	_, err = NewHTTPClient(TransportConfig{AddressFamily: IPv6Only}).Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClientResolver(t *testing.T) {
+	queried := false
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			queried = true
+			return nil, errors.New("no DNS here")
+		},
+	}
+
+	_, err := NewHTTPClient(TransportConfig{Resolver: resolver}).Get("http://updown.invalid")
+	assert.Error(t, err)
+	assert.True(t, queried)
+}
+
+func TestNewHTTPClientTuning(t *testing.T) {
+	transport := NewHTTPClient(TransportConfig{}).Transport.(*http.Transport)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	assert.Equal(t, DefaultTLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.False(t, transport.DisableKeepAlives)
+
+	transport = NewHTTPClient(TransportConfig{
+		MaxIdleConnsPerHost: 200,
+		IdleConnTimeout:     time.Minute,
+		TLSHandshakeTimeout: 5 * time.Second,
+		DisableHTTP2:        true,
+	}).Transport.(*http.Transport)
+	assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.Equal(t, 5*time.Second, transport.TLSHandshakeTimeout)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+}
+
+func TestWithMaxIdleConnsPerHost(t *testing.T) {
+	client, err := NewClient("key", WithMaxIdleConnsPerHost(64))
+	require.NoError(t, err)
+	assert.Equal(t, 64, client.client.Transport.(*http.Transport).MaxIdleConnsPerHost)
+
+	_, err = NewClient("key", WithMaxIdleConnsPerHost(0))
+	assert.Error(t, err)
+
+	_, err = NewClient("key", WithHTTPClient(&http.Client{Transport: roundTripperFunc(nil)}), WithMaxIdleConnsPerHost(8))
+	assert.Error(t, err)
+}
+
+// serveSOCKS5 runs a minimal SOCKS5 proxy requiring the given credentials
+func serveSOCKS5(t *testing.T, user, password string) (addr string, used *atomic.Bool) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	used = new(atomic.Bool)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 512)
+
+				// Greeting: only offer username/password authentication
+				if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+					return
+				}
+				io.ReadFull(conn, buf[:buf[1]])
+				conn.Write([]byte{5, 2})
+
+				// Authentication
+				io.ReadFull(conn, buf[:2])
+				u := make([]byte, buf[1])
+				io.ReadFull(conn, u)
+				io.ReadFull(conn, buf[:1])
+				p := make([]byte, buf[0])
+				io.ReadFull(conn, p)
+				if string(u) != user || string(p) != password {
+					conn.Write([]byte{1, 1})
+					return
+				}
+				conn.Write([]byte{1, 0})
+
+				// Connect request, with an IPv4 or domain name destination
+				io.ReadFull(conn, buf[:4])
+				var host string
+				switch buf[3] {
+				case 1:
+					io.ReadFull(conn, buf[:4])
+					host = net.IP(buf[:4]).String()
+				case 3:
+					io.ReadFull(conn, buf[:1])
+					name := make([]byte, buf[0])
+					io.ReadFull(conn, name)
+					host = string(name)
+				}
+				io.ReadFull(conn, buf[:2])
+				port := int(buf[0])<<8 | int(buf[1])
+
+				target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+				if err != nil {
+					conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer target.Close()
+				used.Store(true)
+				conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+				go io.Copy(target, conn)
+				io.Copy(conn, target)
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), used
+}
+
+func TestNewHTTPClientSOCKS5(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	proxy, used := serveSOCKS5(t, "bastion", "s3cret")
+
+	resp, err := NewHTTPClient(TransportConfig{
+		SOCKS5Proxy:    proxy,
+		SOCKS5User:     "bastion",
+		SOCKS5Password: "s3cret",
+	}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.True(t, used.Load())
+
+	_, err = NewHTTPClient(TransportConfig{
+		SOCKS5Proxy:    proxy,
+		SOCKS5User:     "bastion",
+		SOCKS5Password: "wrong",
+	}).Get(server.URL)
+	assert.Error(t, err)
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	var clientCerts int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCerts = len(r.TLS.PeerCertificates)
+		w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// The certificate of the test server is not trusted by default
+	client := newTestClient(t, server.URL)
+	_, _, err := client.Check.List()
+	assert.Error(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	cfg := &tls.Config{RootCAs: roots, Certificates: server.TLS.Certificates}
+	client = newTestClient(t, server.URL, WithTLSConfig(cfg))
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, 1, clientCerts)
+
+	// Only *http.Transport can be configured
+	_, err = NewClient("key", WithHTTPClient(&http.Client{Transport: roundTripperFunc(nil)}), WithTLSConfig(cfg))
+	assert.Error(t, err)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	client := newTestClient(t, "http://updown.invalid/api", WithProxy(proxy.URL))
+	_, _, err := client.Check.List()
+	require.NoError(t, err)
+	assert.Equal(t, "http://updown.invalid/api/checks", proxied)
+
+	socks, used := serveSOCKS5(t, "bastion", "s3cret")
+	client = newTestClient(t, proxy.URL, WithProxy("socks5://bastion:s3cret@"+socks))
+	_, _, err = client.Check.List()
+	require.NoError(t, err)
+	assert.True(t, used.Load())
+
+	_, err = NewClient("key", WithProxy("ftp://proxy.internal"))
+	assert.Error(t, err)
+	_, err = NewClient("key", WithProxy("http://"))
+	assert.Error(t, err)
+}
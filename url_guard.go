@@ -0,0 +1,121 @@
+package updown
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// internalSuffixes are domain suffixes which only resolve on private networks
+var internalSuffixes = []string{".localhost", ".local", ".internal", ".intranet", ".lan", ".home.arpa", ".corp"}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, not routable on the internet
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PrivateURLError reports a check URL which the updown monitoring nodes cannot reach, as it
+// points to a private, loopback or internal-only host
+type PrivateURLError struct {
+	URL  string
+	Host string
+}
+
+func (e *PrivateURLError) Error() string {
+	return fmt.Sprintf("%s cannot be monitored from the internet: %s is a private host", e.URL, e.Host)
+}
+
+// URLGuard rejects checks of private, loopback and internal-only hosts, see WithURLGuard
+type URLGuard struct {
+	// Hosts allowed anyway, as host names or IP prefixes such as 10.1.0.0/16
+	Allow []string
+
+	// Resolver used to look host names up. Host names resolving to private addresses only are
+	// rejected. Without resolver, only IP addresses and well known internal names are rejected
+	Resolver *net.Resolver
+}
+
+// WithURLGuard rejects adding checks, or updating their URL, when the URL points to a host the
+// updown monitoring nodes can never reach. Add and Update then return a *PrivateURLError
+func WithURLGuard(guard URLGuard) Option {
+	return func(c *Client) error {
+		c.urlGuard = &guard
+		return nil
+	}
+}
+
+// Check verifies that a check URL points to a public host
+func (g URLGuard) Check(ctx context.Context, checkURL string) error {
+	host := checkHost(checkURL)
+	if host == "" || g.allowed(host) {
+		return nil
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if privateAddr(ip) {
+			return &PrivateURLError{URL: checkURL, Host: host}
+		}
+		return nil
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	if name == "localhost" || !strings.Contains(name, ".") {
+		return &PrivateURLError{URL: checkURL, Host: host}
+	}
+	for _, suffix := range internalSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return &PrivateURLError{URL: checkURL, Host: host}
+		}
+	}
+
+	if g.Resolver == nil {
+		return nil
+	}
+	addrs, err := g.Resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, ip := range addrs {
+		if !privateAddr(ip) {
+			return nil
+		}
+	}
+	return &PrivateURLError{URL: checkURL, Host: host}
+}
+
+// allowed tells if the host is in the allow list
+func (g URLGuard) allowed(host string) bool {
+	ip, ipErr := netip.ParseAddr(host)
+	for _, allowed := range g.Allow {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+		if prefix, err := netip.ParsePrefix(allowed); err == nil && ipErr == nil && prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHost gives the host of a check URL, which is a bare host for ICMP checks
+func checkHost(checkURL string) string {
+	if strings.Contains(checkURL, "://") {
+		u, err := url.Parse(checkURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+	if host, _, err := net.SplitHostPort(checkURL); err == nil {
+		return host
+	}
+	return strings.Trim(checkURL, "[]")
+}
+
+// privateAddr tells if an IP address is not reachable from the internet
+func privateAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,77 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLGuardCheck(t *testing.T) {
+	guard := URLGuard{Allow: []string{"intranet.example.com", "10.1.0.0/16"}}
+	ctx := context.Background()
+
+	for _, u := range []string{
+		"https://10.0.0.1/health",
+		"http://192.168.1.10:8080",
+		"https://localhost",
+		"https://[::1]/",
+		"tcp://db.internal:5432",
+		"https://printer.local",
+		"http://jenkins/",
+		"172.16.0.1",
+		"100.64.1.1",
+		"https://169.254.169.254/latest/meta-data",
+	} {
+		var private *PrivateURLError
+		assert.True(t, errors.As(guard.Check(ctx, u), &private), u)
+	}
+
+	for _, u := range []string{
+		"https://example.com",
+		"8.8.8.8",
+		"tcp://db.example.com:5432",
+		"https://[2001:4860:4860::8888]/",
+		"https://intranet.example.com",
+		"https://10.1.2.3/health",
+	} {
+		assert.NoError(t, guard.Check(ctx, u), u)
+	}
+}
+
+func TestURLGuardResolver(t *testing.T) {
+	guard := URLGuard{Resolver: &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, errors.New("no DNS in tests")
+		},
+	}}
+	assert.Error(t, guard.Check(context.Background(), "https://example.com"))
+}
+
+func TestWithURLGuard(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"token":"abcd"}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL, WithURLGuard(URLGuard{}))
+
+	_, _, err := client.Check.Add(CheckItem{URL: "https://192.168.1.1"})
+	assert.Equal(t, &PrivateURLError{URL: "https://192.168.1.1", Host: "192.168.1.1"}, err)
+	_, _, err = client.Check.Update("abcd", CheckItem{URL: "http://localhost:8080"})
+	assert.Error(t, err)
+	assert.Zero(t, calls)
+
+	_, _, err = client.Check.Add(CheckItem{URL: "https://example.com"})
+	require.NoError(t, err)
+	_, _, err = client.Check.Update("abcd", CheckItem{Alias: "Example"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
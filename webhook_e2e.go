@@ -0,0 +1,104 @@
+package updown
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	webhookTestCheckPath   = "/updown-webhook-test/check"
+	webhookTestReceivePath = "/updown-webhook-test/webhook"
+)
+
+// ErrWebhookNotDelivered indicates that no alert reached the local handler in time
+var ErrWebhookNotDelivered = errors.New("No webhook was delivered before the timeout")
+
+// WebhookTest describes an end-to-end test of the webhook alert path
+type WebhookTest struct {
+	// Public URL forwarding to ListenAddr, e.g. an ngrok tunnel
+	PublicURL string
+	// Local address the test handler listens on, e.g. ":8080"
+	ListenAddr string
+	// Maximum time to wait for the alert, 5 minutes by default
+	Timeout time.Duration
+}
+
+// TestWebhook validates the full alert path: it serves a local handler, registers a
+// temporary webhook recipient pointing at it, creates a temporary check which always
+// fails and waits for the resulting alert to be delivered. The recipient and the check
+// are removed afterwards
+func (s *RecipientService) TestWebhook(ctx context.Context, test WebhookTest) error {
+	timeout := test.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delivered := make(chan string, 16)
+	mux := http.NewServeMux()
+	mux.HandleFunc(webhookTestCheckPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc(webhookTestReceivePath, func(w http.ResponseWriter, r *http.Request) {
+		if events, err := ParseWebhookEvents(r.Body); err == nil {
+			for _, event := range events {
+				select {
+				case delivered <- event.Check.Token:
+				default:
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	listener, err := net.Listen("tcp", test.ListenAddr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	publicURL := strings.TrimSuffix(test.PublicURL, "/")
+	recipient, _, err := s.AddCtx(ctx, RecipientItem{
+		Type:  RecipientTypeWebhook,
+		Value: publicURL + webhookTestReceivePath,
+		Name:  "updown webhook test",
+	})
+	if err != nil {
+		return err
+	}
+	// Clean up even when the context is done
+	defer s.Remove(recipient.ID)
+
+	check, _, err := s.client.Check.AddCtx(ctx, CheckItem{
+		URL:          publicURL + webhookTestCheckPath,
+		Alias:        "updown webhook test",
+		Period:       15,
+		Enabled:      true,
+		RecipientIDs: []string{recipient.ID},
+	})
+	if err != nil {
+		return err
+	}
+	defer s.client.Check.Remove(check.Token)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrWebhookNotDelivered
+			}
+			return ctx.Err()
+		case token := <-delivered:
+			if token == check.Token {
+				return nil
+			}
+		}
+	}
+}
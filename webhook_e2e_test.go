@@ -0,0 +1,91 @@
+package updown
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebhookAPI fakes the recipients and checks endpoints, posting a check.down event to
+// the webhook recipient once the check is created when deliver is set
+func fakeWebhookAPI(t *testing.T, deliver bool) (server *httptest.Server, removed func() []string) {
+	var mu sync.Mutex
+	var webhookURL string
+	var deleted []string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/recipients":
+			var item RecipientItem
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&item))
+			webhookURL = item.Value
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Recipient{ID: "webhook:1", Type: item.Type, Value: item.Value})
+		case r.Method == "POST" && r.URL.Path == "/checks":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token":"abcd"}`))
+			if deliver {
+				go http.Post(webhookURL, mediaType, strings.NewReader(`[{"event":"check.down","check":{"token":"abcd"}}]`))
+			}
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			w.Write([]byte(`{"deleted":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return deleted
+	}
+}
+
+// freeAddr gives a local address nothing listens on
+func freeAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestTestWebhook(t *testing.T) {
+	server, removed := fakeWebhookAPI(t, true)
+	client := newTestClient(t, server.URL)
+
+	addr := freeAddr(t)
+	err := client.Recipient.TestWebhook(context.Background(), WebhookTest{
+		PublicURL:  "http://" + addr + "/",
+		ListenAddr: addr,
+		Timeout:    5 * time.Second,
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/recipients/webhook:1", "/checks/abcd"}, removed())
+}
+
+func TestTestWebhookNotDelivered(t *testing.T) {
+	server, removed := fakeWebhookAPI(t, false)
+	client := newTestClient(t, server.URL)
+
+	addr := freeAddr(t)
+	err := client.Recipient.TestWebhook(context.Background(), WebhookTest{
+		PublicURL:  "http://" + addr,
+		ListenAddr: addr,
+		Timeout:    100 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, ErrWebhookNotDelivered)
+
+	// Resources are removed even though the deadline passed
+	assert.ElementsMatch(t, []string{"/recipients/webhook:1", "/checks/abcd"}, removed())
+}
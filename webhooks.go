@@ -1,6 +1,7 @@
 package updown
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -18,23 +19,27 @@ type WebhookService struct {
 
 // List lists all the webhooks
 func (s *WebhookService) List() ([]Webhook, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "webhooks", nil)
-	if err != nil {
-		return nil, nil, err
-	}
+	return s.ListCtx(context.Background())
+}
 
-	var res []Webhook
-	resp, err := s.client.Do(req, &res)
+// ListCtx is like List, with a context
+func (s *WebhookService) ListCtx(ctx context.Context) ([]Webhook, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "GET", "webhooks", nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
 
-	return res, resp, err
+	return do[[]Webhook](s.client, req)
 }
 
 // Add adds a new webhook you want to be performed
 func (s *WebhookService) Add(webhook Webhook) (Webhook, *http.Response, error) {
-	req, err := s.client.NewRequest("POST", "webhooks", webhook)
+	return s.AddCtx(context.Background(), webhook)
+}
+
+// AddCtx is like Add, with a context
+func (s *WebhookService) AddCtx(ctx context.Context, webhook Webhook) (Webhook, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "POST", "webhooks", webhook)
 	if err != nil {
 		return webhook, nil, err
 	}
@@ -50,7 +55,12 @@ func (s *WebhookService) Add(webhook Webhook) (Webhook, *http.Response, error) {
 
 // Remove removes a webhook from Updown by its ID
 func (s *WebhookService) Remove(id string) (bool, *http.Response, error) {
-	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("webhooks/%s", id), nil)
+	return s.RemoveCtx(context.Background(), id)
+}
+
+// RemoveCtx is like Remove, with a context
+func (s *WebhookService) RemoveCtx(ctx context.Context, id string) (bool, *http.Response, error) {
+	req, err := s.client.NewRequestCtx(ctx, "DELETE", fmt.Sprintf("webhooks/%s", id), nil)
 	if err != nil {
 		return false, nil, err
 	}